@@ -0,0 +1,289 @@
+package ahocorasick
+
+import "sort"
+
+// A Metric measures the distance between a pattern and the input it matches approximately.
+type Metric int
+
+const (
+	Hamming     Metric = iota // The number of substituted bytes.
+	Levenshtein               // The number of substituted, inserted and deleted bytes.
+)
+
+func (metric Metric) String() string {
+	switch metric {
+	case Hamming:
+		return "Hamming"
+	case Levenshtein:
+		return "Levenshtein"
+	}
+	return "Metric(?)"
+}
+
+// An ApproxBuilder builds an ApproxTrie.
+type ApproxBuilder struct {
+	metric   Metric
+	k        int64
+	patterns [][]byte
+}
+
+// Create a new ApproxBuilder for matches within distance k (as measured by metric) of patterns.
+func NewApproxBuilder(metric Metric, k int64) *ApproxBuilder {
+	return &ApproxBuilder{
+		metric:   metric,
+		k:        k,
+		patterns: make([][]byte, 0),
+	}
+}
+
+// Add a pattern to be built into the ApproxTrie. The pattern is given an ID equal to the number of
+// patterns added before it (see Match.ID). Patterns no longer than k are ignored, as they would
+// match anywhere.
+func (ab *ApproxBuilder) AddPattern(pattern []byte) *ApproxBuilder {
+	ab.patterns = append(ab.patterns, pattern)
+	return ab
+}
+
+// A helper method to make adding multiple patterns a little more comfortable.
+func (ab *ApproxBuilder) AddPatterns(patterns [][]byte) *ApproxBuilder {
+	for _, pattern := range patterns {
+		ab.AddPattern(pattern)
+	}
+	return ab
+}
+
+// A helper method to make adding a string pattern more comfortable.
+func (ab *ApproxBuilder) AddString(pattern string) *ApproxBuilder {
+	return ab.AddPattern([]byte(pattern))
+}
+
+// A helper method to make adding multiple string patterns a little more comfortable.
+func (ab *ApproxBuilder) AddStrings(patterns []string) *ApproxBuilder {
+	for _, pattern := range patterns {
+		ab.AddString(pattern)
+	}
+	return ab
+}
+
+// Build the ApproxTrie. Each pattern is split into k+1 pieces, of which (by the pigeonhole
+// principle) at least one must occur exactly in any match within distance k. A Trie is built from
+// the pieces, and each match of a piece is verified against the whole pattern.
+func (ab *ApproxBuilder) Build() *ApproxTrie {
+	at := &ApproxTrie{
+		metric:   ab.metric,
+		k:        ab.k,
+		patterns: append([][]byte(nil), ab.patterns...),
+		refs:     make([][]pieceRef, 0),
+	}
+
+	tb := NewTrieBuilder()
+	pieceIDs := make(map[string]int64)
+
+	for i, pattern := range at.patterns {
+		m := int64(len(pattern))
+		if m <= at.k {
+			continue
+		}
+
+		for j := int64(0); j <= at.k; j++ {
+			from, to := j*m/(at.k+1), (j+1)*m/(at.k+1)
+			piece := pattern[from:to]
+
+			id, ok := pieceIDs[string(piece)]
+			if !ok {
+				id = int64(len(at.refs))
+				pieceIDs[string(piece)] = id
+				at.refs = append(at.refs, nil)
+				tb.AddPatternWithID(piece, id)
+			}
+			at.refs[id] = append(at.refs[id], pieceRef{int64(i), from})
+		}
+	}
+
+	at.trie = tb.Build()
+
+	return at
+}
+
+// An ApproxTrie matches patterns approximately, reporting matches within a distance k.
+type ApproxTrie struct {
+	trie     *Trie        // Matches the pieces.
+	metric   Metric       // The metric of the distance.
+	k        int64        // The maximum distance.
+	patterns [][]byte     // The patterns, indexed by ID.
+	refs     [][]pieceRef // The patterns containing each piece, indexed by piece ID.
+}
+
+// A reference from a piece to a pattern containing it.
+type pieceRef struct {
+	pattern int64 // The ID of the pattern.
+	offset  int64 // The offset of the piece in the pattern.
+}
+
+// Run the ApproxTrie against the provided input and return matches, ordered by their end position,
+// along with their distance from the pattern (see Match.Distance). Match.Match returns the matched
+// bytes of the input.
+//
+// A pattern can usually be matched at several overlapping positions around an occurrence, e.g.
+// with an extra byte in front. So only the best matches of each pattern are returned: those with
+// the smallest distance (and then the earliest position) not overlapping each other.
+func (at *ApproxTrie) Match(input []byte) []*Match {
+	type candidate struct{ id, start int64 }
+	best := make(map[candidate]*Match)
+
+	for _, piece := range at.trie.Match(input) {
+		for _, ref := range at.refs[piece.ID()] {
+			pattern := at.patterns[ref.pattern]
+			start := piece.Pos() - ref.offset
+
+			// With insertions and deletions, the match may start up to k bytes from the start
+			// implied by the piece.
+			from, to := start, start
+			if at.metric == Levenshtein {
+				from, to = start-at.k, start+at.k
+			}
+
+			for s := from; s <= to; s++ {
+				if s < 0 || s > int64(len(input)) {
+					continue
+				}
+
+				c := candidate{ref.pattern, s}
+				if _, ok := best[c]; ok {
+					continue
+				}
+
+				best[c] = nil
+
+				if end, d, ok := at.verify(pattern, input, s); ok {
+					best[c] = newMatch(s, ref.pattern, input[s:end])
+					best[c].distance = d
+				}
+			}
+		}
+	}
+
+	candidates := make([]*Match, 0, len(best))
+	for _, m := range best {
+		if m != nil {
+			candidates = append(candidates, m)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		if a.pos != b.pos {
+			return a.pos < b.pos
+		}
+		return a.id < b.id
+	})
+
+	// Pick the best matches of each pattern which do not overlap.
+	matches := make([]*Match, 0)
+	picked := make(map[int64][]*Match)
+
+	for _, m := range candidates {
+		overlaps := false
+		for _, p := range picked[m.id] {
+			if m.pos < p.End() && p.pos < m.End() {
+				overlaps = true
+				break
+			}
+		}
+
+		if !overlaps {
+			picked[m.id] = append(picked[m.id], m)
+			matches = append(matches, m)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].End() != matches[j].End() {
+			return matches[i].End() < matches[j].End()
+		}
+		return matches[i].pos < matches[j].pos
+	})
+
+	return matches
+}
+
+// Helper method to make matching strings a little more comfortable.
+func (at *ApproxTrie) MatchString(input string) []*Match {
+	return at.Match([]byte(input))
+}
+
+// Verify a match of pattern starting at start in input, returning its end and distance.
+func (at *ApproxTrie) verify(pattern, input []byte, start int64) (int64, int64, bool) {
+	if at.metric == Levenshtein {
+		n, d, ok := levenshteinPrefix(pattern, input[start:], at.k)
+		return start + n, d, ok
+	}
+
+	end := start + int64(len(pattern))
+	if end > int64(len(input)) {
+		return 0, 0, false
+	}
+
+	var d int64
+	for i, c := range pattern {
+		if input[start+int64(i)] != c {
+			if d++; d > at.k {
+				return 0, 0, false
+			}
+		}
+	}
+
+	return end, d, true
+}
+
+// Find the prefix of text with the smallest Levenshtein distance to pattern (preferring the
+// longest of equally distant prefixes), returning its length and distance if within k.
+func levenshteinPrefix(pattern, text []byte, k int64) (int64, int64, bool) {
+	m := int64(len(pattern))
+	if n := m + k; int64(len(text)) > n {
+		text = text[:n]
+	}
+
+	// row[j] is the distance between the pattern so far and text[:j].
+	row := make([]int64, len(text)+1)
+	for j := range row {
+		row[j] = int64(j)
+	}
+
+	for i := int64(1); i <= m; i++ {
+		diag := row[0]
+		row[0] = i
+
+		for j := 1; j < len(row); j++ {
+			cost := diag
+			if pattern[i-1] != text[j-1] {
+				cost++
+			}
+
+			diag = row[j]
+			if row[j]+1 < cost {
+				cost = row[j] + 1
+			}
+			if row[j-1]+1 < cost {
+				cost = row[j-1] + 1
+			}
+			row[j] = cost
+		}
+	}
+
+	end := 0
+	for j := range row {
+		if row[j] <= row[end] {
+			end = j
+		}
+	}
+
+	if row[end] > k {
+		return 0, 0, false
+	}
+
+	return int64(end), row[end], true
+}
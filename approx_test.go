@@ -0,0 +1,93 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Format matches like "{pos match distance}".
+func approxStrings(matches []*Match) string {
+	s := make([]string, len(matches))
+	for i, m := range matches {
+		s[i] = fmt.Sprintf("{%d %q %d}", m.Pos(), m.Match(), m.Distance())
+	}
+	return fmt.Sprint(s)
+}
+
+func TestApproxTrieHamming(t *testing.T) {
+	at := NewApproxBuilder(Hamming, 1).AddStrings([]string{"hello", "world"}).Build()
+
+	cases := []struct {
+		input, expected string
+	}{
+		{"hello", `[{0 "hello" 0}]`},
+		{"jello hallo", `[{0 "jello" 1} {6 "hallo" 1}]`},
+		{"world worlds wxrld", `[{0 "world" 0} {6 "world" 0} {13 "wxrld" 1}]`},
+		{"helo", `[]`},
+		{"hxxlo", `[]`},
+	}
+
+	for _, c := range cases {
+		if got := approxStrings(at.MatchString(c.input)); got != c.expected {
+			t.Errorf("%q: expected %s, got %s", c.input, c.expected, got)
+		}
+	}
+}
+
+func TestApproxTrieLevenshtein(t *testing.T) {
+	at := NewApproxBuilder(Levenshtein, 2).AddStrings([]string{"receive", "package"}).Build()
+
+	cases := []struct {
+		input, expected string
+	}{
+		{"receive", `[{0 "receive" 0}]`},
+		{"recieve the packege", `[{0 "recieve" 2} {12 "packege" 1}]`},
+		{"xxreceivexx", `[{2 "receive" 0}]`},
+		{"recive", `[{0 "recive" 1}]`},
+		{"recceivve", `[{0 "recceivve" 2}]`},
+		{"rxcxixe", `[]`},
+	}
+
+	for _, c := range cases {
+		if got := approxStrings(at.MatchString(c.input)); got != c.expected {
+			t.Errorf("%q: expected %s, got %s", c.input, c.expected, got)
+		}
+	}
+}
+
+func TestApproxTrieIDs(t *testing.T) {
+	at := NewApproxBuilder(Levenshtein, 1).AddStrings([]string{"a", "cat", "dog"}).Build()
+
+	matches := at.MatchString("a cot and a dg")
+	if got := approxStrings(matches); got != `[{2 "cot" 1} {12 "dg" 1}]` {
+		t.Fatalf("unexpected matches: %s", got)
+	}
+
+	if matches[0].ID() != 1 || matches[1].ID() != 2 {
+		t.Errorf("expected IDs 1 and 2, got %d and %d", matches[0].ID(), matches[1].ID())
+	}
+}
+
+func TestLevenshteinPrefix(t *testing.T) {
+	cases := []struct {
+		pattern, text string
+		end, d        int64
+		ok            bool
+	}{
+		{"abc", "abc", 3, 0, true},
+		{"abc", "abcd", 3, 0, true},
+		{"abc", "abxc", 4, 1, true},
+		{"abc", "abd", 3, 1, true},
+		{"abc", "axbc", 4, 1, true},
+		{"abc", "ac", 2, 1, true},
+		{"abc", "xyz", 0, 0, false},
+	}
+
+	for _, c := range cases {
+		end, d, ok := levenshteinPrefix([]byte(c.pattern), []byte(c.text), 1)
+		if end != c.end || d != c.d || ok != c.ok {
+			t.Errorf("%q in %q: expected (%d, %d, %v), got (%d, %d, %v)",
+				c.pattern, c.text, c.end, c.d, c.ok, end, d, ok)
+		}
+	}
+}
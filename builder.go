@@ -9,31 +9,139 @@ const (
 
 // A TrieBuilder must be used to properly build Tries.
 type TrieBuilder struct {
-	base  []int64
-	check []int64
-	dict  []int64
-	fail  []int64
-	suff  []int64
+	base       []int64
+	check      []int64
+	dict       []int64
+	fail       []int64
+	suff       []int64
+	ids        []int64
+	nextID     int64              // The ID given to the next pattern added without an explicit ID.
+	tags       map[int64][]string // The tags of patterns, by ID.
+	priorities map[int64]int64    // The priorities of patterns, by ID.
+	transform  Transform          // Applied to patterns, and by the Trie to input.
+	options    Options            // The options of the Trie.
+
+	validation    ValidationMode   // How problems with patterns are handled.
+	maxPatternLen int64            // The maximum length of patterns when validating, if positive.
+	maxStates     int64            // The maximum number of states when validating, if positive.
+	numStates     int64            // The number of states, including the root.
+	added         int64            // The number of patterns added, including left out ones.
+	seen          map[string]int64 // The index of each pattern added, when validating.
+	overflowed    bool             // Whether exceeding maxStates has been reported.
+	err           error            // The first problem, in Strict mode.
+	warnings      []error          // The problems, in Lenient mode.
 }
 
 // Create and initialize a new TrieBuilder.
 func NewTrieBuilder() *TrieBuilder {
 	tb := &TrieBuilder{
-		base:  make([]int64, 0),
-		check: make([]int64, 0),
-		dict:  make([]int64, 0),
-		fail:  make([]int64, 0),
-		suff:  make([]int64, 0),
+		base:       make([]int64, 0),
+		check:      make([]int64, 0),
+		dict:       make([]int64, 0),
+		fail:       make([]int64, 0),
+		suff:       make([]int64, 0),
+		ids:        make([]int64, 0),
+		tags:       make(map[int64][]string),
+		priorities: make(map[int64]int64),
 	}
 
 	// Add the root state.
 	tb.addState()
+	tb.numStates = 1
 
 	return tb
 }
 
-// Add a new pattern to be built into the resulting Trie.
+// Reserve room for about nPatterns more patterns of totalBytes bytes in total, to avoid growing the
+// arrays repeatedly while adding them.
+func (tb *TrieBuilder) Grow(nPatterns, totalBytes int64) *TrieBuilder {
+	// Each byte adds at most one state, but states are spread out over the cells.
+	cells := len(tb.base) + int(totalBytes+AlphabetSize+1)
+
+	for _, arr := range []*[]int64{&tb.base, &tb.check, &tb.dict, &tb.ids} {
+		if cap(*arr) < cells {
+			grown := make([]int64, len(*arr), cells)
+			copy(grown, *arr)
+			*arr = grown
+		}
+	}
+
+	if tb.seen != nil && len(tb.seen) == 0 {
+		tb.seen = make(map[string]int64, nPatterns)
+	}
+
+	return tb
+}
+
+// Remove all patterns, so that another Trie can be built reusing the allocated arrays. The
+// transform, options and validation settings are kept.
+func (tb *TrieBuilder) Reset() *TrieBuilder {
+	tb.base = tb.base[:0]
+	tb.check = tb.check[:0]
+	tb.dict = tb.dict[:0]
+	tb.ids = tb.ids[:0]
+
+	tb.nextID = 0
+	for id := range tb.tags {
+		delete(tb.tags, id)
+	}
+	for id := range tb.priorities {
+		delete(tb.priorities, id)
+	}
+
+	tb.added = 0
+	if tb.seen != nil {
+		tb.seen = make(map[string]int64)
+	}
+	tb.overflowed = false
+	tb.err = tb.options.validate()
+	tb.warnings = nil
+
+	// Add the root state.
+	tb.addState()
+	tb.numStates = 1
+
+	return tb
+}
+
+// Add a new pattern to be built into the resulting Trie. The pattern is given an ID equal to the
+// number of patterns added before it (see Match.ID).
 func (tb *TrieBuilder) AddPattern(pattern []byte) *TrieBuilder {
+	return tb.AddPatternWithID(pattern, tb.nextID)
+}
+
+// Add a new pattern with a given (non-negative) ID, which is reported by Match.ID. Adding the same
+// pattern twice keeps the last ID. Patterns added afterwards without an explicit ID continue
+// counting from this one.
+func (tb *TrieBuilder) AddPatternWithID(pattern []byte, id int64) *TrieBuilder {
+	tb.addPattern(pattern, id)
+	return tb
+}
+
+// Add a pattern with the given ID, returning whether it was added to the trie (e.g. not left out by
+// validation).
+func (tb *TrieBuilder) addPattern(pattern []byte, id int64) bool {
+	tb.nextID = id + 1
+
+	if tb.err != nil {
+		return false // Strict validation failed, or the options are invalid.
+	}
+
+	if tb.transform != nil {
+		pattern = tb.transform(pattern)
+	}
+
+	index := tb.added
+	tb.added++
+
+	if tb.validation != NoValidation && !tb.validate(pattern, index) {
+		return false
+	}
+
+	if len(pattern) == 0 {
+		return false // Empty patterns never match.
+	}
+
 	s := RootState
 
 	for _, c := range pattern {
@@ -43,6 +151,7 @@ func (tb *TrieBuilder) AddPattern(pattern []byte) *TrieBuilder {
 			// Cell is empty: expand arrays and set transition.
 			tb.expandArrays(t)
 			tb.check[t] = s
+			tb.numStates++
 		} else if tb.check[t] == s {
 			// Cell is in use by s, simply move on.
 		} else {
@@ -67,6 +176,7 @@ func (tb *TrieBuilder) AddPattern(pattern []byte) *TrieBuilder {
 
 			// Set transition.
 			tb.check[t] = s
+			tb.numStates++
 		}
 
 		// Move to next state.
@@ -75,7 +185,85 @@ func (tb *TrieBuilder) AddPattern(pattern []byte) *TrieBuilder {
 
 	// Mark s as in dictionary by setting pattern len in dict.
 	tb.dict[s] = int64(len(pattern))
+	tb.ids[s] = id
+
+	if tb.validation != NoValidation {
+		tb.validateStates(pattern, index)
+	}
+
+	return true
+}
+
+// Add a new pattern with one or more tags, which are reported by Match.Tags and used to group
+// matches by Trie.MatchByTag. The pattern is given an ID like with AddPattern. The tags are not
+// attached if the pattern is left out (e.g. by validation).
+func (tb *TrieBuilder) AddPatternWithTags(pattern []byte, tags ...string) *TrieBuilder {
+	id := tb.nextID
+	if tb.addPattern(pattern, id) {
+		tb.Tag(id, tags...)
+	}
+	return tb
+}
+
+// A helper method to make adding a string pattern with tags more comfortable.
+func (tb *TrieBuilder) AddStringWithTags(pattern string, tags ...string) *TrieBuilder {
+	return tb.AddPatternWithTags([]byte(pattern), tags...)
+}
 
+// Attach tags to the pattern with the given ID, e.g. one added with AddPatternWithID. Tags already
+// attached to the pattern are kept.
+func (tb *TrieBuilder) Tag(id int64, tags ...string) *TrieBuilder {
+	for _, tag := range tags {
+		if !containsString(tb.tags[id], tag) {
+			tb.tags[id] = append(tb.tags[id], tag)
+		}
+	}
+	return tb
+}
+
+// Add a new pattern with a priority, which decides between overlapping matches when matching with
+// Matcher.NonOverlapping (higher wins). Patterns have priority 0 by default. The pattern is given an
+// ID like with AddPattern. The priority is not set if the pattern is left out (e.g. by validation).
+func (tb *TrieBuilder) AddPatternWithPriority(pattern []byte, priority int64) *TrieBuilder {
+	id := tb.nextID
+	if tb.addPattern(pattern, id) {
+		tb.Priority(id, priority)
+	}
+	return tb
+}
+
+// A helper method to make adding a string pattern with a priority more comfortable.
+func (tb *TrieBuilder) AddStringWithPriority(pattern string, priority int64) *TrieBuilder {
+	return tb.AddPatternWithPriority([]byte(pattern), priority)
+}
+
+// Set the priority of the pattern with the given ID, e.g. one added with AddPatternWithID.
+func (tb *TrieBuilder) Priority(id, priority int64) *TrieBuilder {
+	if priority == 0 {
+		delete(tb.priorities, id)
+	} else {
+		tb.priorities[id] = priority
+	}
+	return tb
+}
+
+func containsString(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Set a Transform (e.g. NFC) which is applied to patterns added afterwards, and which the built Trie
+// applies to input before matching. Match positions still refer to the original input. Note that the
+// transform is not saved by SaveTrie or GenerateGo; the CaseFolding and Normalization options (see
+// NewTrieBuilderWithOptions) are, and are replaced by t.
+func (tb *TrieBuilder) Transform(t Transform) *TrieBuilder {
+	tb.transform = t
+	tb.options.CaseFolding = false // Replaced by t.
+	tb.options.Normalization = NoNormalization
 	return tb
 }
 
@@ -100,17 +288,16 @@ func (tb *TrieBuilder) AddStrings(patterns []string) *TrieBuilder {
 	return tb
 }
 
-// Build the trie.
+// Build the trie. When validating in Strict mode, or with options from NewTrieBuilderWithOptions,
+// check Err before using the trie, which then only holds the patterns added before the problem.
+//
+// The Trie is a snapshot of the patterns added so far: the TrieBuilder can still be used to add more
+// patterns (or be Reset) and build again, without affecting Tries built earlier.
 func (tb *TrieBuilder) Build() *Trie {
 
-	// Initialize link arrays.
-	tb.fail = make([]int64, len(tb.base))
-	tb.suff = make([]int64, len(tb.base))
-
-	for i := 0; i < len(tb.base); i++ {
-		tb.fail[i] = EmptyCell
-		tb.suff[i] = EmptyCell
-	}
+	// Initialize link arrays (reusing them from earlier builds).
+	tb.fail = extendCells(tb.fail[:0], len(tb.base), EmptyCell)
+	tb.suff = extendCells(tb.suff[:0], len(tb.base), EmptyCell)
 
 	// Root fails to itself.
 	tb.fail[RootState] = RootState
@@ -123,13 +310,19 @@ func (tb *TrieBuilder) Build() *Trie {
 		tb.computeSuffLink(s)
 	}
 
-	// Should I copy these slices over or?
+	// The Trie gets copies of everything, so it is not affected by further use of the builder (and
+	// does not hold on to the spare capacity of the arrays).
 	return &Trie{
-		base:  tb.base,
-		check: tb.check,
-		dict:  tb.dict,
-		fail:  tb.fail,
-		suff:  tb.suff,
+		base:       trimCells(tb.base),
+		check:      trimCells(tb.check),
+		dict:       trimCells(tb.dict),
+		fail:       trimCells(tb.fail),
+		suff:       trimCells(tb.suff),
+		ids:        trimCells(tb.ids),
+		tags:       copyTags(tb.tags),
+		priorities: copyPriorities(tb.priorities),
+		transform:  tb.transform,
+		options:    tb.options,
 	}
 }
 
@@ -193,15 +386,75 @@ func (tb *TrieBuilder) computeSuffLink(s int64) {
 }
 
 func (tb *TrieBuilder) addState() {
-	tb.base = append(tb.base, DefaultBase)
-	tb.check = append(tb.check, EmptyCell)
-	tb.dict = append(tb.dict, 0)
+	tb.expandArrays(int64(len(tb.base)))
 }
 
+// Expand the arrays to hold cell n, in one step.
 func (tb *TrieBuilder) expandArrays(n int64) {
-	for int64(len(tb.base)) <= n {
-		tb.addState()
+	k := int(n) + 1 - len(tb.base)
+	if k <= 0 {
+		return
+	}
+
+	tb.base = extendCells(tb.base, k, DefaultBase)
+	tb.check = extendCells(tb.check, k, EmptyCell)
+	tb.dict = extendCells(tb.dict, k, 0)
+	tb.ids = extendCells(tb.ids, k, EmptyCell)
+}
+
+// Extend cells by k cells set to v, at least doubling the capacity if it must grow.
+func extendCells(cells []int64, k int, v int64) []int64 {
+	n := len(cells) + k
+
+	if n > cap(cells) {
+		c := 2 * cap(cells)
+		if c < n {
+			c = n
+		}
+		grown := make([]int64, len(cells), c)
+		copy(grown, cells)
+		cells = grown
+	}
+
+	cells = cells[:n]
+	for i := n - k; i < n; i++ {
+		cells[i] = v
+	}
+
+	return cells
+}
+
+// Get a deep copy of tags, or nil if there are none.
+func copyTags(tags map[int64][]string) map[int64][]string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	copied := make(map[int64][]string, len(tags))
+	for id, t := range tags {
+		copied[id] = append([]string(nil), t...)
 	}
+	return copied
+}
+
+// Get a copy of priorities, or nil if there are none.
+func copyPriorities(priorities map[int64]int64) map[int64]int64 {
+	if len(priorities) == 0 {
+		return nil
+	}
+
+	copied := make(map[int64]int64, len(priorities))
+	for id, p := range priorities {
+		copied[id] = p
+	}
+	return copied
+}
+
+// Get a copy of cells without spare capacity.
+func trimCells(cells []int64) []int64 {
+	trimmed := make([]int64, len(cells))
+	copy(trimmed, cells)
+	return trimmed
 }
 
 // Get all c's for which state s has a transition (that is, where check[base[s]+c] == s).
@@ -262,6 +515,7 @@ func (tb *TrieBuilder) relocate(s int64) {
 		tb.check[t_] = s         // Mark s as owner of t'.
 		tb.base[t_] = tb.base[t] // Copy base value.
 		tb.dict[t_] = tb.dict[t] // As well as the dictionary value.
+		tb.ids[t_] = tb.ids[t]   // And the pattern ID.
 
 		// We must also update all states which had transitions from t to t'.
 		for c := int64(0); c < AlphabetSize+1; c++ {
@@ -279,6 +533,7 @@ func (tb *TrieBuilder) relocate(s int64) {
 		// Unset old tb.check and dictionary values for t.
 		tb.check[t] = EmptyCell
 		tb.dict[t] = 0
+		tb.ids[t] = EmptyCell
 	}
 
 	// Finally we can move the base for s.
@@ -1,6 +1,9 @@
 package ahocorasick
 
-import "fmt"
+import (
+	"fmt"
+	"testing"
+)
 
 func ExampleTrieBuilder_Build() {
 	builder := NewTrieBuilder()
@@ -13,3 +16,106 @@ func ExampleTrieBuilder_Build() {
 	fmt.Println(len(trie.MatchString("hello!")))
 	// Output: 1
 }
+
+func TestBuildTrimmed(t *testing.T) {
+	tb := NewTrieBuilder().Grow(4, 100).AddStrings([]string{"hers", "his", "he", "she"})
+	trie := tb.Build()
+
+	for i, arr := range [][]int64{trie.base, trie.check, trie.dict, trie.fail, trie.suff, trie.ids} {
+		if len(arr) != len(tb.base) || cap(arr) != len(arr) {
+			t.Errorf("array %d: expected length and capacity %d, got %d and %d", i, len(tb.base), len(arr), cap(arr))
+		}
+	}
+
+	if &trie.base[0] == &tb.base[0] {
+		t.Error("expected the trie to get a copy of the arrays")
+	}
+}
+
+func TestGrow(t *testing.T) {
+	patterns := []string{"hers", "his", "he", "she"}
+
+	tb := NewTrieBuilder().Grow(int64(len(patterns)), 12)
+	c := cap(tb.base)
+
+	tb.AddStrings(patterns)
+
+	if cap(tb.base) != c {
+		t.Errorf("expected capacity to stay %d, got %d", c, cap(tb.base))
+	}
+
+	expected := fmt.Sprint(NewTrieBuilder().AddStrings(patterns).Build().MatchString("ushers"))
+	if got := fmt.Sprint(tb.Build().MatchString("ushers")); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestReset(t *testing.T) {
+	tb := NewTrieBuilder().Validate(Lenient).AddStrings([]string{"hers", "his", "he", "she", "he"})
+	first := tb.Build()
+	c := cap(tb.base)
+
+	second := tb.Reset().AddStringWithTags("is", "verb").AddString("hi").Build()
+
+	if cap(tb.base) != c {
+		t.Errorf("expected arrays to be reused (capacity %d), got capacity %d", c, cap(tb.base))
+	}
+
+	if len(tb.Warnings()) != 0 {
+		t.Errorf("expected warnings to be reset, got %v", tb.Warnings())
+	}
+
+	cases := []struct {
+		trie     *Trie
+		expected string
+	}{
+		{first, `[{1 "his"} {5 "he"} {5 "hers"}]`},
+		{second, `[{1 "hi"} {2 "is"}]`},
+	}
+
+	for i, c := range cases {
+		if got := fmt.Sprint(c.trie.MatchString("this hers")); got != c.expected {
+			t.Errorf("trie %d: expected %s, got %s", i, c.expected, got)
+		}
+	}
+
+	if ids := fmt.Sprint(second.MatchString("his")[0].ID(), second.MatchString("his")[1].Tags()); ids != "1 [verb]" {
+		t.Errorf("expected IDs counting from 0 after Reset, got %s", ids)
+	}
+}
+
+func TestBuildSnapshot(t *testing.T) {
+	tb := NewTrieBuilder().AddStringWithTags("he", "pronoun").AddStrings([]string{"hers", "she"})
+	first := tb.Build()
+
+	// Adding patterns relocates states of the first ones.
+	tb.AddStrings([]string{"his", "hi", "is", "ushers"}).Tag(0, "short").Priority(1, 5)
+	second := tb.Build()
+
+	input := "ushers his"
+
+	expected := `[{1 "she"} {2 "he"} {2 "hers"}]`
+	if got := fmt.Sprint(first.MatchString(input)); got != expected {
+		t.Errorf("expected first trie to match %s, got %s", expected, got)
+	}
+
+	expected = `[{1 "she"} {2 "he"} {0 "ushers"} {2 "hers"} {7 "hi"} {7 "his"} {8 "is"}]`
+	if got := fmt.Sprint(second.MatchString(input)); got != expected {
+		t.Errorf("expected second trie to match %s, got %s", expected, got)
+	}
+
+	if tags := fmt.Sprint(first.tags[0], second.tags[0]); tags != "[pronoun] [pronoun short]" {
+		t.Errorf("expected tags [pronoun] and [pronoun short], got %s", tags)
+	}
+
+	if first.priorities != nil || second.priorities[1] != 5 {
+		t.Errorf("expected priority 5 only in the second trie, got %v and %v", first.priorities, second.priorities)
+	}
+
+	if err := first.Verify(); err != nil {
+		t.Errorf("first trie: %v", err)
+	}
+	if err := second.Verify(); err != nil {
+		t.Errorf("second trie: %v", err)
+	}
+}
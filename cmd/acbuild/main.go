@@ -0,0 +1,79 @@
+// Command acbuild compiles pattern files into a trie file which can be loaded with LoadTrie.
+//
+// Usage:
+//
+//     acbuild [flags] -o <trie-file> <pattern-file>...
+//
+// The format of each pattern file is given by its extension, unless overridden with -format:
+//
+//     .txt (or anything else)  text, one pattern on each line
+//     .hex                     hex, one pattern on each line (see ReadHex)
+//     .csv                     CSV records of the form id,pattern (an optional id,pattern header is skipped)
+//
+// Patterns from text and hex files are given IDs counting from 0 in the order they are read. Empty
+// and duplicate patterns are skipped with a warning (keeping the first occurrence).
+//
+// The exit code is 0 on success, 1 if there were warnings and -strict was given (in which case no
+// trie file is written) and 2 on errors.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+func main() {
+	output := flag.String("o", "", "write the trie to `file`")
+	format := flag.String("format", "", "read all pattern files as `format` (text, hex or csv)")
+	strict := flag.Bool("strict", false, "treat warnings as errors")
+	quiet := flag.Bool("q", false, "do not print warnings and build statistics")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] -o <trie-file> <pattern-file>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *output == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ps := newPatternSet()
+
+	for _, path := range flag.Args() {
+		if err := ps.readFile(path, *format); err != nil {
+			fmt.Fprintf(os.Stderr, "acbuild: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	if !*quiet {
+		for _, w := range ps.warnings {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+		}
+	}
+
+	if *strict && len(ps.warnings) > 0 {
+		fmt.Fprintf(os.Stderr, "acbuild: %d warnings, not writing %s\n", len(ps.warnings), *output)
+		os.Exit(1)
+	}
+
+	start := time.Now()
+	trie := ps.build(ahocorasick.NewTrieBuilder())
+	elapsed := time.Since(start)
+
+	if err := ahocorasick.SaveTrie(trie, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "acbuild: %v\n", err)
+		os.Exit(2)
+	}
+
+	if !*quiet {
+		fmt.Fprintf(os.Stderr, "built %d patterns into %d states in %v (%d warnings)\n",
+			len(ps.patterns), trie.Stats().NumStates, elapsed, len(ps.warnings))
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+// A pattern and where it was read from.
+type pattern struct {
+	id      int64
+	pattern []byte
+	source  string // As file:line.
+}
+
+// A set of unique patterns read from one or more files.
+type patternSet struct {
+	patterns []*pattern
+	seen     map[string]*pattern // Patterns by content.
+	ids      map[int64]*pattern  // Patterns by ID.
+	nextID   int64               // The ID of the next pattern from a text or hex file.
+	warnings []string
+}
+
+func newPatternSet() *patternSet {
+	return &patternSet{
+		patterns: make([]*pattern, 0),
+		seen:     make(map[string]*pattern),
+		ids:      make(map[int64]*pattern),
+		warnings: make([]string, 0),
+	}
+}
+
+// Read patterns from path in the given format, or the format given by the extension of path if
+// format is empty.
+func (ps *patternSet) readFile(path, format string) error {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".hex":
+			format = "hex"
+		case ".csv":
+			format = "csv"
+		default:
+			format = "text"
+		}
+	}
+
+	switch format {
+	case "text":
+		return ps.readList(path, ahocorasick.ReadStrings)
+	case "hex":
+		return ps.readList(path, ahocorasick.ReadHex)
+	case "csv":
+		return ps.readCSV(path)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// Read patterns (one on each line) with read, giving them sequential IDs.
+func (ps *patternSet) readList(path string, read func(string) ([][]byte, error)) error {
+	patterns, err := read(path)
+	if err != nil {
+		return err
+	}
+
+	for i, p := range patterns {
+		ps.add(ps.nextID, p, fmt.Sprintf("%s:%d", path, i+1))
+	}
+
+	return nil
+}
+
+// Read id,pattern records from a CSV file.
+func (ps *patternSet) readCSV(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 2
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		line, _ := r.FieldPos(0)
+		source := fmt.Sprintf("%s:%d", path, line)
+
+		id, err := strconv.ParseInt(strings.TrimSpace(record[0]), 10, 64)
+		if err != nil {
+			if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "id") {
+				continue // Header.
+			}
+			return fmt.Errorf("%s: invalid ID %q", source, record[0])
+		}
+		if id < 0 {
+			return fmt.Errorf("%s: negative ID %d", source, id)
+		}
+
+		ps.add(id, []byte(record[1]), source)
+	}
+}
+
+// Add a pattern to the set, skipping (with a warning) empty patterns and duplicates.
+func (ps *patternSet) add(id int64, p []byte, source string) {
+	if id >= ps.nextID {
+		ps.nextID = id + 1
+	}
+
+	if len(p) == 0 {
+		ps.warn("%s: empty pattern", source)
+		return
+	}
+
+	if first, ok := ps.seen[string(p)]; ok {
+		ps.warn("%s: duplicate pattern %q (first seen at %s)", source, p, first.source)
+		return
+	}
+
+	if first, ok := ps.ids[id]; ok {
+		ps.warn("%s: duplicate ID %d (first seen at %s)", source, id, first.source)
+	}
+
+	pat := &pattern{id, p, source}
+	ps.patterns = append(ps.patterns, pat)
+	ps.seen[string(p)] = pat
+	ps.ids[id] = pat
+}
+
+func (ps *patternSet) warn(format string, args ...interface{}) {
+	ps.warnings = append(ps.warnings, fmt.Sprintf(format, args...))
+}
+
+// Add all patterns to tb and build the trie.
+func (ps *patternSet) build(tb *ahocorasick.TrieBuilder) *ahocorasick.Trie {
+	for _, p := range ps.patterns {
+		tb.AddPatternWithID(p.pattern, p.id)
+	}
+	return tb.Build()
+}
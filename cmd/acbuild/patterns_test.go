@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+func TestPatternSet(t *testing.T) {
+	ps := newPatternSet()
+
+	for _, path := range []string{"testdata/words.txt", "testdata/words.csv", "testdata/words.hex"} {
+		if err := ps.readFile(path, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectedWarnings := []string{
+		"testdata/words.txt:3: empty pattern",
+		`testdata/words.txt:5: duplicate pattern "his" (first seen at testdata/words.txt:2)`,
+		"testdata/words.csv:4: duplicate ID 100 (first seen at testdata/words.csv:2)",
+		`testdata/words.hex:1: duplicate pattern "he" (first seen at testdata/words.txt:4)`,
+		`testdata/words.hex:2: duplicate pattern "she" (first seen at testdata/words.csv:2)`,
+	}
+
+	if len(ps.warnings) != len(expectedWarnings) {
+		t.Fatalf("expected %d warnings, got %d: %q", len(expectedWarnings), len(ps.warnings), ps.warnings)
+	}
+	for i := range expectedWarnings {
+		if ps.warnings[i] != expectedWarnings[i] {
+			t.Errorf("expected warning %q, got %q", expectedWarnings[i], ps.warnings[i])
+		}
+	}
+
+	trie := ps.build(ahocorasick.NewTrieBuilder())
+
+	expected := map[string]int64{"hers": 0, "his": 1, "he": 3, "she": 100, "he, said": 101, "hi": 100}
+	if trie.NumPatterns() != int64(len(expected)) {
+		t.Errorf("expected %d patterns, got %d", len(expected), trie.NumPatterns())
+	}
+
+	for _, m := range trie.MatchString("she said: he, said hers, his") {
+		if id, ok := expected[m.MatchString()]; !ok || id != m.ID() {
+			t.Errorf("%v: expected ID %d, got %d", m, id, m.ID())
+		}
+	}
+}
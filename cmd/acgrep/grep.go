@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+// Searches input with a trie and prints the results.
+type grep struct {
+	trie      *ahocorasick.Trie
+	w         io.Writer
+	errw      io.Writer // Where files which could not be searched are reported.
+	failed    bool      // Whether any file could not be searched.
+	countOnly bool
+	filesOnly bool
+	foldCase  bool
+	wholeWord bool
+	json      bool
+}
+
+// A single result, as printed with -json.
+type result struct {
+	File    string `json:"file"`
+	Line    int64  `json:"line"`
+	Offset  int64  `json:"offset"`
+	Pattern string `json:"pattern"`
+}
+
+// Search all paths, returning the exit code.
+func (g *grep) run(paths []string) int {
+	matched := false
+
+	for _, path := range paths {
+		err := g.searchPath(path, func(n int64) {
+			if n > 0 {
+				matched = true
+			}
+		})
+		if err != nil {
+			g.fail(err)
+		}
+	}
+
+	switch {
+	case g.failed:
+		return 2
+	case matched:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Search a file, a directory (recursively) or standard input ("-"), calling done with the number
+// of matches in each file searched. Files which cannot be opened are reported (see fail), and the
+// search goes on.
+func (g *grep) searchPath(path string, done func(n int64)) error {
+	if path == "-" {
+		n, err := g.search("(standard input)", os.Stdin)
+		done(n)
+		return err
+	}
+
+	return filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			g.fail(err)
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			g.fail(err)
+			return nil
+		}
+		defer f.Close()
+
+		n, err := g.search(path, f)
+		done(n)
+		return err
+	})
+}
+
+// Report an error, making the exit code 2.
+func (g *grep) fail(err error) {
+	fmt.Fprintf(g.errw, "acgrep: %v\n", err)
+	g.failed = true
+}
+
+// Search r line by line, returning the number of matches.
+func (g *grep) search(name string, r io.Reader) (int64, error) {
+	br := bufio.NewReader(r)
+	enc := json.NewEncoder(g.w)
+
+	var count, offset int64
+	var lower []byte
+
+	for lineNum := int64(1); ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			if err == nil {
+				err = g.printCount(enc, name, count)
+			}
+			return count, err
+		}
+
+		input := line
+		if g.foldCase {
+			lower = append(lower[:0], line...)
+			toLower(lower)
+			input = lower
+		}
+
+		for _, m := range g.trie.Match(input) {
+			if g.wholeWord && !isWholeWord(line, m.Pos(), m.End()) {
+				continue
+			}
+
+			count++
+
+			if g.filesOnly {
+				if err := g.printFile(enc, name); err != nil {
+					return count, err
+				}
+				return count, nil
+			}
+
+			if g.countOnly {
+				continue
+			}
+
+			res := result{
+				File:    name,
+				Line:    lineNum,
+				Offset:  offset + m.Pos(),
+				Pattern: string(line[m.Pos():m.End()]),
+			}
+			if err := g.printResult(enc, res); err != nil {
+				return count, err
+			}
+		}
+
+		offset += int64(len(line))
+	}
+}
+
+func (g *grep) printResult(enc *json.Encoder, res result) error {
+	if g.json {
+		return enc.Encode(res)
+	}
+	_, err := fmt.Fprintf(g.w, "%s:%d:%d:%s\n", res.File, res.Line, res.Offset, res.Pattern)
+	return err
+}
+
+func (g *grep) printCount(enc *json.Encoder, name string, count int64) error {
+	if !g.countOnly {
+		return nil
+	}
+	if g.json {
+		return enc.Encode(struct {
+			File  string `json:"file"`
+			Count int64  `json:"count"`
+		}{name, count})
+	}
+	_, err := fmt.Fprintf(g.w, "%s:%d\n", name, count)
+	return err
+}
+
+func (g *grep) printFile(enc *json.Encoder, name string) error {
+	if g.json {
+		return enc.Encode(struct {
+			File string `json:"file"`
+		}{name})
+	}
+	_, err := fmt.Fprintln(g.w, name)
+	return err
+}
+
+// Check whether the match line[pos:end] is not surrounded by word bytes.
+func isWholeWord(line []byte, pos, end int64) bool {
+	if pos > 0 && isWordByte(line[pos-1]) {
+		return false
+	}
+	if end < int64(len(line)) && isWordByte(line[end]) {
+		return false
+	}
+	return true
+}
+
+// Word bytes are ASCII letters, digits, underscore and any non-ASCII byte (so that UTF-8 encoded
+// letters are treated as part of words).
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '_' || b >= 0x80
+}
+
+// Fold ASCII upper case letters in b to lower case (in place). Only ASCII is folded so that
+// offsets stay the same.
+func toLower(b []byte) {
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+}
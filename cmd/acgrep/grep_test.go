@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+const input = "He said hello.\nShe said: Hello, hello!\nOthello.\n"
+
+func TestSearch(t *testing.T) {
+	cases := []struct {
+		name     string
+		grep     grep
+		expected string
+	}{
+		{
+			"Default",
+			grep{},
+			"test:1:8:hello\ntest:2:32:hello\ntest:3:41:hello\n",
+		},
+		{
+			"FoldCase",
+			grep{foldCase: true},
+			"test:1:8:hello\ntest:2:25:Hello\ntest:2:32:hello\ntest:3:41:hello\n",
+		},
+		{
+			"WholeWord",
+			grep{foldCase: true, wholeWord: true},
+			"test:1:8:hello\ntest:2:25:Hello\ntest:2:32:hello\n",
+		},
+		{
+			"Count",
+			grep{countOnly: true},
+			"test:3\n",
+		},
+		{
+			"Files",
+			grep{filesOnly: true},
+			"test\n",
+		},
+		{
+			"JSON",
+			grep{wholeWord: true, json: true},
+			`{"file":"test","line":1,"offset":8,"pattern":"hello"}` + "\n" +
+				`{"file":"test","line":2,"offset":32,"pattern":"hello"}` + "\n",
+		},
+	}
+
+	for _, c := range cases {
+		var buf bytes.Buffer
+
+		g := c.grep
+		g.trie = ahocorasick.NewTrieBuilder().AddString("hello").Build()
+		g.w = &buf
+
+		if _, err := g.search("test", strings.NewReader(input)); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+
+		if buf.String() != c.expected {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", c.name, c.expected, buf.String())
+		}
+	}
+}
+
+func TestSearchUnreadable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acgrep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	unreadable := filepath.Join(dir, "a.txt")
+	matching := filepath.Join(dir, "b.txt")
+
+	if err := ioutil.WriteFile(unreadable, []byte(input), 0000); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(matching, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err := os.Open(unreadable); err == nil {
+		f.Close()
+		t.Skip("permission to read any file (e.g. running as root)")
+	}
+
+	var out, errs bytes.Buffer
+	g := grep{
+		trie:      ahocorasick.NewTrieBuilder().AddString("hello").Build(),
+		w:         &out,
+		errw:      &errs,
+		countOnly: true,
+	}
+
+	if code := g.run([]string{dir}); code != 2 {
+		t.Errorf("expected exit code %d, got %d", 2, code)
+	}
+
+	if expected := matching + ":3\n"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+
+	if !strings.Contains(errs.String(), unreadable) {
+		t.Errorf("expected %s to be reported, got %q", unreadable, errs.String())
+	}
+}
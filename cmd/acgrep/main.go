@@ -0,0 +1,89 @@
+// Command acgrep searches files for many patterns at once using an Aho-Corasick trie.
+//
+// Usage:
+//
+//     acgrep [flags] (-f <patterns> | -x <hex-patterns> | -t <trie-file>) [path...]
+//
+// Patterns are read one on each line, either as text (-f) or hex (-x), or a prebuilt trie written
+// by SaveTrie is loaded (-t). Directories are searched recursively, and standard input is searched
+// if no paths (or "-") are given.
+//
+// Each match is printed as file:line:offset:pattern, where the line number is 1-based and the
+// byte offset is 0-based from the start of the file. Matching is done line by line, so patterns
+// containing newlines never match.
+//
+// The exit code is 0 if anything matched, 1 if nothing matched and 2 on errors.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+func main() {
+	textFile := flag.String("f", "", "read text patterns from `file`, one on each line")
+	hexFile := flag.String("x", "", "read hex patterns from `file`, one on each line")
+	trieFile := flag.String("t", "", "load a prebuilt trie from `file`")
+
+	g := new(grep)
+	flag.BoolVar(&g.countOnly, "c", false, "only print the number of matches in each file")
+	flag.BoolVar(&g.filesOnly, "l", false, "only print the names of files with matches")
+	flag.BoolVar(&g.foldCase, "i", false, "ignore ASCII case (a prebuilt trie must hold lowercase patterns)")
+	flag.BoolVar(&g.wholeWord, "w", false, "only report matches forming whole words")
+	flag.BoolVar(&g.json, "json", false, "print results as JSON, one object on each line")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(),
+			"Usage: %s [flags] (-f <patterns> | -x <hex-patterns> | -t <trie-file>) [path...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var err error
+
+	switch {
+	case *textFile != "" && *hexFile == "" && *trieFile == "":
+		g.trie, err = buildTrie(ahocorasick.ReadStrings, *textFile, g.foldCase)
+	case *hexFile != "" && *textFile == "" && *trieFile == "":
+		g.trie, err = buildTrie(ahocorasick.ReadHex, *hexFile, g.foldCase)
+	case *trieFile != "" && *textFile == "" && *hexFile == "":
+		g.trie, err = ahocorasick.LoadTrie(*trieFile)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "acgrep: %v\n", err)
+		os.Exit(2)
+	}
+
+	g.w = os.Stdout
+	g.errw = os.Stderr
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	os.Exit(g.run(paths))
+}
+
+// Read patterns with read and build a trie from them.
+func buildTrie(read func(string) ([][]byte, error), path string, foldCase bool) (*ahocorasick.Trie, error) {
+	patterns, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if foldCase {
+		for _, pattern := range patterns {
+			toLower(pattern)
+		}
+	}
+
+	return ahocorasick.NewTrieBuilder().AddPatterns(patterns).Build(), nil
+}
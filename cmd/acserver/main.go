@@ -0,0 +1,46 @@
+// Command acserver serves matching against a trie file over HTTP.
+//
+// Usage:
+//
+//     acserver [flags] <trie-file>
+//
+// Endpoints:
+//
+//     POST /match    match the request body, responding with {"matches": [{"offset", "end", "id"}]}
+//     POST /reload   reload the trie file, atomically replacing the trie in use
+//     GET  /healthz  respond with {"status": "ok", "patterns": <number of patterns>}
+//
+// Request bodies larger than -max-body bytes are rejected with 413 Request Entity Too Large.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen on `address`")
+	maxBody := flag.Int64("max-body", 1<<20, "maximum request body size in `bytes`")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <trie-file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	srv := newServer(flag.Arg(0), *maxBody)
+	if err := srv.reload(); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Serving %s on %s", flag.Arg(0), *addr)
+	log.Fatal(http.ListenAndServe(*addr, srv))
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+// Serves matching against a trie loaded from file.
+type server struct {
+	path    string       // The trie file.
+	maxBody int64        // Maximum request body size in bytes.
+	trie    atomic.Value // The *ahocorasick.Trie in use.
+	mu      sync.Mutex   // Serializes reloads.
+	mux     *http.ServeMux
+}
+
+// A match as returned by /match.
+type match struct {
+	Offset int64 `json:"offset"`
+	End    int64 `json:"end"`
+	ID     int64 `json:"id"`
+}
+
+func newServer(path string, maxBody int64) *server {
+	srv := &server{
+		path:    path,
+		maxBody: maxBody,
+		mux:     http.NewServeMux(),
+	}
+
+	srv.mux.HandleFunc("/match", srv.handleMatch)
+	srv.mux.HandleFunc("/reload", srv.handleReload)
+	srv.mux.HandleFunc("/healthz", srv.handleHealthz)
+
+	return srv
+}
+
+func (srv *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.mux.ServeHTTP(w, r)
+}
+
+// Load and verify the trie file, and swap it in if valid. Requests in progress keep using the
+// trie they started with.
+func (srv *server) reload() error {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	trie, err := ahocorasick.LoadTrie(srv.path)
+	if err != nil {
+		return err
+	}
+
+	if err := trie.Verify(); err != nil {
+		return err
+	}
+
+	srv.trie.Store(trie)
+	return nil
+}
+
+func (srv *server) currentTrie() *ahocorasick.Trie {
+	return srv.trie.Load().(*ahocorasick.Trie)
+}
+
+func (srv *server) handleMatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	input, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, srv.maxBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+		} else {
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	matches := make([]match, 0)
+	for _, m := range srv.currentTrie().Match(input) {
+		matches = append(matches, match{m.Pos(), m.End(), m.ID()})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"matches": matches})
+}
+
+func (srv *server) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if err := srv.reload(); err != nil {
+		log.Printf("Reload failed: %v", err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"patterns": srv.currentTrie().NumPatterns()})
+}
+
+func (srv *server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":   "ok",
+		"patterns": srv.currentTrie().NumPatterns(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Writing response failed: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acserver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test.trie")
+	save := func(patterns ...string) {
+		trie := ahocorasick.NewTrieBuilder().AddStrings(patterns).Build()
+		if err := ahocorasick.SaveTrie(trie, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	save("hers", "his", "he", "she")
+
+	srv := newServer(path, 16)
+	if err := srv.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	request := func(method, path, body string, status int, expected string) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.StatusCode != status {
+			t.Errorf("%s %s: expected status %d, got %d", method, path, status, res.StatusCode)
+		}
+		if expected != "" && string(b) != expected+"\n" {
+			t.Errorf("%s %s: expected %s, got %s", method, path, expected, b)
+		}
+	}
+
+	request("GET", "/healthz", "", 200, `{"patterns":4,"status":"ok"}`)
+	request("POST", "/match", "ushers", 200,
+		`{"matches":[{"offset":1,"end":4,"id":3},{"offset":2,"end":4,"id":2},{"offset":2,"end":6,"id":0}]}`)
+	request("POST", "/match", "nothing", 200, `{"matches":[]}`)
+	request("GET", "/match", "", 405, "")
+	request("POST", "/match", strings.Repeat("x", 17), 413, "")
+
+	save("he", "she")
+	request("POST", "/reload", "", 200, `{"patterns":2}`)
+	request("POST", "/match", "ushers", 200,
+		`{"matches":[{"offset":1,"end":4,"id":1},{"offset":2,"end":4,"id":0}]}`)
+
+	if err := ioutil.WriteFile(path, []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	request("POST", "/reload", "", 500, "")
+	request("GET", "/healthz", "", 200, `{"patterns":2,"status":"ok"}`)
+}
+
+func TestMatchBodyError(t *testing.T) {
+	srv := newServer("", 16)
+	srv.trie.Store(ahocorasick.NewTrieBuilder().AddString("he").Build())
+
+	body := io.MultiReader(strings.NewReader("he"), iotest.ErrReader(errors.New("connection reset")))
+	req := httptest.NewRequest("POST", "/match", body)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
@@ -0,0 +1,75 @@
+// Command ahocorasick-gen generates Go source code for a Trie built from a pattern file, so that
+// the Trie can be compiled into a binary instead of loaded at startup.
+//
+// It is meant to be used with go generate, e.g.:
+//
+//     //go:generate ahocorasick-gen -func keywordTrie -o keywords_trie.go keywords.txt
+//
+// The package name defaults to $GOPACKAGE (set by go generate).
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("ahocorasick-gen: ")
+
+	output := flag.String("o", "", "write generated code to `file` instead of stdout")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package `name` of the generated code")
+	fn := flag.String("func", "newTrie", "`name` of the generated function returning the trie")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <patterns-file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *pkg == "" {
+		*pkg = "main"
+	}
+
+	src, err := generate(flag.Arg(0), *pkg, *fn)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = ioutil.WriteFile(*output, src, 0644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Build a trie from the patterns in path and generate code for it.
+func generate(path, pkg, fn string) ([]byte, error) {
+	patterns, err := ahocorasick.ReadStrings(path)
+	if err != nil {
+		return nil, err
+	}
+
+	trie := ahocorasick.NewTrieBuilder().AddPatterns(patterns).Build()
+
+	var buf bytes.Buffer
+	if err := ahocorasick.GenerateGo(trie, &buf, pkg, fn); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
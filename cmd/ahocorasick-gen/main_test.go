@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+//go:generate go run . -pkg main -func generatedTrie -o trie_gen_test.go testdata/patterns.txt
+
+func TestGenerateUpToDate(t *testing.T) {
+	src, err := generate("testdata/patterns.txt", "main", "generatedTrie")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := ioutil.ReadFile("trie_gen_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(src, expected) {
+		t.Error("trie_gen_test.go is out of date, run go generate")
+	}
+}
+
+func TestGeneratedTrie(t *testing.T) {
+	patterns, err := ahocorasick.ReadStrings("testdata/patterns.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input, err := ioutil.ReadFile("../../test_data/Ibsen.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ahocorasick.NewTrieBuilder().AddPatterns(patterns).Build().Match(input)
+	matches := generatedTrie().Match(input)
+
+	if len(matches) == 0 {
+		t.Fatal("expected some matches")
+	}
+
+	if len(matches) != len(expected) {
+		t.Fatalf("expected %d matches, got %d", len(expected), len(matches))
+	}
+
+	for i := range matches {
+		if !ahocorasick.MatchEqual(matches[i], expected[i]) {
+			t.Errorf("expected %v, got %v", expected[i], matches[i])
+		}
+	}
+}
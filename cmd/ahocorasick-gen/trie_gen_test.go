@@ -0,0 +1,124 @@
+// Code generated by go-ahocorasick. DO NOT EDIT.
+
+package main
+
+import "github.com/BobuSumisu/go-ahocorasick"
+
+var generatedTrieBase = [...]int64{
+	105, 19, 0, 0, 0, 0, 0, 0, 46, 0, 0, 50, 54, 0, 38, 0,
+	0, 0, 31, 0, 29, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 12, 23, 26, 26, 32, 5, 64, 60, 36, 0, 57, 57, 75, 0, 1,
+	67, 32, 35, 15, 23, 42, 2, 6, 1, 55, 36, 0, 21, 55, 91, 3,
+	52, 2, 15, 1, 0, 47, 32, 0, 67, 1, 15, 3, 37, 57, 6, 0,
+	34, 71, 1, 5, 6, 55, 74, 76, 0, 62, 0, 90, 32, 75, 21, 15,
+	88, 21, 36, 85, 34, 0, 52, 52, 3, 89, 19, 35, 15, 15, 23, 90,
+	32, 46, 6, 2, 50, 54, 57, 38, 0, 75, 0, 31, 26, 29, 35, 3,
+	42, 74, 2, 0, 96, 1, 0, 0, 0, 0, 0, 76, 0, 0, 58, 0,
+	3, 2, 1, 46, 0, 32, 23, 0, 0, 55, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0,
+}
+
+var generatedTrieCheck = [...]int64{
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 240, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, 13, 67, 130, 88, 141, 47, 145, 42, 115, 75, 54, 56, 78, 101, 82,
+	65, 83, 120, 90, 66, 63, 230, 38, 150, 97, 60, 34, 109, 95, 33, 36,
+	123, 106, 51, 74, 70, 52, 35, 94, 126, 41, 110, 80, 125, 49, 92, 98,
+	58, 100, 107, 113, 37, 119, 53, 76, 128, 103, 118, 61, 153, 43, 77, 117,
+	102, 40, 64, 147, 116, 163, 134, 146, 48, 85, 0, 44, 142, 39, 81, 129,
+	45, 0, 89, 86, 0, 0, 121, 0, 131, 72, 105, 0, 96, 0, 139, 91,
+	93, 99, 87, 141, 62, 132, 144, -1, 111, -1, -1, 0, -1, 0, 111, -1,
+	0, 0, 108, 0, -1, 0, 0, -1, -1, 0, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, 149, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, 84, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	104,
+}
+
+var generatedTrieDict = [...]int64{
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4, 0,
+	0, 0, 4, 0, 0, 0, 0, 8, 0, 11, 0, 8, 0, 0, 0, 0,
+	0, 0, 0, 0, 7, 10, 0, 6, 0, 16, 0, 0, 0, 0, 0, 4,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	5, 0, 10, 0, 0, 0, 0, 0, 0, 4, 4, 0, 5, 0, 0, 5,
+	0, 0, 0, 0, 0, 10, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0,
+}
+
+var generatedTrieFail = [...]int64{
+	0, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 0, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, 0, 0, 0, 141, 0, 0, 0, 145, 144, 144, 0, 0, 144, 0, 0,
+	0, 149, 0, 0, 0, 0, 0, 0, 0, 141, 0, 141, 150, 0, 0, 37,
+	0, 0, 0, 0, 141, 0, 149, 0, 0, 0, 0, 0, 0, 150, 0, 0,
+	149, 0, 0, 0, 0, 0, 149, 0, 0, 0, 0, 141, 0, 141, 0, 0,
+	149, 39, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 150, 144, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 141, 0, 0, 37,
+	0, 149, 0, 0, 0, 0, 0, -1, 0, -1, -1, 0, -1, 0, 0, -1,
+	0, 0, 0, 0, -1, 0, 0, -1, -1, 0, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, 0, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, 0, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	0,
+}
+
+var generatedTrieSuff = [...]int64{
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1,
+}
+
+var generatedTrieIDs = [...]int64{
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 12, -1,
+	-1, -1, 17, -1, -1, -1, -1, 9, -1, 13, -1, 4, -1, -1, -1, -1,
+	-1, -1, -1, -1, 2, 15, -1, 1, -1, 16, -1, -1, -1, -1, -1, 3,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	10, -1, 8, -1, -1, -1, -1, -1, -1, 14, 0, -1, 11, -1, -1, 5,
+	-1, -1, -1, -1, -1, 6, -1, -1, 7, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1,
+}
+
+// generatedTrie returns the compiled-in Trie.
+func generatedTrie() *ahocorasick.Trie {
+	return ahocorasick.NewTrie(generatedTrieBase[:], generatedTrieCheck[:], generatedTrieDict[:], generatedTrieFail[:], generatedTrieSuff[:], generatedTrieIDs[:])
+}
@@ -0,0 +1,124 @@
+// Command trieinfo verifies trie files written by SaveTrie and prints statistics about them.
+//
+// Usage:
+//
+//     trieinfo [-q] <trie-file>...
+//
+// The exit code is 1 if any of the files could not be loaded or is invalid.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+// The width of the longest bar in the depth histogram.
+const histogramWidth = 50
+
+func main() {
+	quiet := flag.Bool("q", false, "only verify the files, do not print statistics")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <trie-file>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	status := 0
+
+	for _, path := range flag.Args() {
+		if err := info(os.Stdout, path, *quiet); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			status = 1
+		}
+	}
+
+	os.Exit(status)
+}
+
+// Load and verify the trie in path, and print its statistics to w (unless quiet).
+func info(w io.Writer, path string, quiet bool) error {
+	trie, err := ahocorasick.LoadTrie(path)
+	if err != nil {
+		return err
+	}
+
+	if err := trie.Verify(); err != nil {
+		return err
+	}
+
+	if quiet {
+		return nil
+	}
+
+	return printStats(w, path, trie.Stats())
+}
+
+func printStats(w io.Writer, path string, st *ahocorasick.TrieStats) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 1, ' ', 0)
+
+	fmt.Fprintf(tw, "file:\t%s\n", path)
+	fmt.Fprintf(tw, "states:\t%d\n", st.NumStates)
+	fmt.Fprintf(tw, "patterns:\t%d\n", st.NumPatterns)
+	fmt.Fprintf(tw, "array length:\t%d\n", st.NumCells)
+	fmt.Fprintf(tw, "fill ratio:\t%.2f%%\n", st.FillRatio*100)
+	fmt.Fprintf(tw, "max depth:\t%d\n", st.MaxDepth)
+	fmt.Fprintf(tw, "average depth:\t%.2f\n", st.AvgDepth)
+	fmt.Fprintf(tw, "longest fail chain:\t%d\n", st.LongestFailChain)
+	fmt.Fprintf(tw, "longest suffix chain:\t%d\n", st.LongestSuffChain)
+	fmt.Fprintf(tw, "memory usage:\t%d bytes (%s)\n", st.MemoryUsage, humanize(st.MemoryUsage))
+
+	names := make([]string, 0, len(st.ArraySizes))
+	for name := range st.ArraySizes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s:\t%d bytes\n", name, st.ArraySizes[name])
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "depth histogram:")
+	tw = tabwriter.NewWriter(w, 0, 8, 1, ' ', tabwriter.AlignRight)
+
+	var max int64
+	for _, n := range st.Depths {
+		if n > max {
+			max = n
+		}
+	}
+
+	for d, n := range st.Depths {
+		bar := int((n*histogramWidth + max - 1) / max)
+		fmt.Fprintf(tw, "  %d\t%d\t %s\n", d, n, strings.Repeat("#", bar))
+	}
+
+	return tw.Flush()
+}
+
+// Format a byte size with a binary unit.
+func humanize(n int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	f := float64(n)
+	i := 0
+	for f >= 1024 && i < len(units)-1 {
+		f /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", f, units[i])
+}
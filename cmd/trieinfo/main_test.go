@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/BobuSumisu/go-ahocorasick"
+)
+
+func TestInfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trieinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test.trie")
+	trie := ahocorasick.NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+	if err := ahocorasick.SaveTrie(trie, path); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := info(&buf, path, false); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{`states:\s+10\n`, `patterns:\s+4\n`, `\s+4\s+1 #+\n`} {
+		if !regexp.MustCompile(line).MatchString(buf.String()) {
+			t.Errorf("expected output to match %q, got:\n%s", line, buf.String())
+		}
+	}
+
+	if err := ioutil.WriteFile(path, []byte("not a trie"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := info(&buf, path, true); err == nil {
+		t.Error("expected error for invalid trie file")
+	}
+}
@@ -0,0 +1,115 @@
+package ahocorasick
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The number of array values written on each line of generated code.
+const valuesPerLine = 16
+
+// Write Go source code to w which declares the arrays of tr as literals, along with a function
+// named fn (in package pkg) returning the Trie. This allows a Trie to be compiled into a binary
+// instead of being loaded from file at startup.
+func GenerateGo(tr *Trie, w io.Writer, pkg, fn string) error {
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "// Code generated by go-ahocorasick. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintln(&buf, `import "github.com/BobuSumisu/go-ahocorasick"`)
+	fmt.Fprintln(&buf)
+
+	arrays := []struct {
+		name string
+		arr  []int64
+	}{
+		{fn + "Base", tr.base},
+		{fn + "Check", tr.check},
+		{fn + "Dict", tr.dict},
+		{fn + "Fail", tr.fail},
+		{fn + "Suff", tr.suff},
+		{fn + "IDs", tr.ids},
+	}
+
+	for _, a := range arrays {
+		fmt.Fprintf(&buf, "var %s = [...]int64{", a.name)
+		for i, v := range a.arr {
+			if i%valuesPerLine == 0 {
+				fmt.Fprint(&buf, "\n\t")
+			} else {
+				fmt.Fprint(&buf, " ")
+			}
+			fmt.Fprintf(&buf, "%d,", v)
+		}
+		fmt.Fprint(&buf, "\n}\n\n")
+	}
+
+	if len(tr.tags) > 0 {
+		ids := make([]int64, 0, len(tr.tags))
+		for id := range tr.tags {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		fmt.Fprintf(&buf, "var %sTags = map[int64][]string{\n", fn)
+		for _, id := range ids {
+			fmt.Fprintf(&buf, "\t%d: {", id)
+			for i, tag := range tr.tags[id] {
+				if i > 0 {
+					fmt.Fprint(&buf, ", ")
+				}
+				fmt.Fprintf(&buf, "%q", tag)
+			}
+			fmt.Fprint(&buf, "},\n")
+		}
+		fmt.Fprint(&buf, "}\n\n")
+	}
+
+	if len(tr.priorities) > 0 {
+		ids := make([]int64, 0, len(tr.priorities))
+		for id := range tr.priorities {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		fmt.Fprintf(&buf, "var %sPriorities = map[int64]int64{\n", fn)
+		for _, id := range ids {
+			fmt.Fprintf(&buf, "\t%d: %d,\n", id, tr.priorities[id])
+		}
+		fmt.Fprint(&buf, "}\n\n")
+	}
+
+	fmt.Fprintf(&buf, "// %s returns the compiled-in Trie.\n", fn)
+	fmt.Fprintf(&buf, "func %s() *ahocorasick.Trie {\n", fn)
+	fmt.Fprint(&buf, "\treturn ahocorasick.NewTrie(")
+	for i, a := range arrays {
+		if i > 0 {
+			fmt.Fprint(&buf, ", ")
+		}
+		fmt.Fprintf(&buf, "%s[:]", a.name)
+	}
+	fmt.Fprint(&buf, ")")
+	if len(tr.tags) > 0 {
+		fmt.Fprintf(&buf, ".WithTags(%sTags)", fn)
+	}
+	if len(tr.priorities) > 0 {
+		fmt.Fprintf(&buf, ".WithPriorities(%sPriorities)", fn)
+	}
+	if opts := tr.options.goCode(); len(opts) > 0 {
+		fmt.Fprintf(&buf, ".WithOptions(%s)", strings.Join(opts, ", "))
+	}
+	fmt.Fprint(&buf, "\n}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(src)
+	return err
+}
@@ -0,0 +1,74 @@
+package ahocorasick
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestGenerateGo(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+
+	var buf bytes.Buffer
+	if err := GenerateGo(trie, &buf, "keywords", "keywordTrie"); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), "keywords.go", buf.Bytes(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Name.Name != "keywords" {
+		t.Errorf("expected package %q, got %q", "keywords", f.Name.Name)
+	}
+
+	if f.Scope.Lookup("keywordTrie") == nil {
+		t.Error("expected function keywordTrie to be declared")
+	}
+
+	for _, name := range []string{"Base", "Check", "Dict", "Fail", "Suff", "IDs"} {
+		if f.Scope.Lookup("keywordTrie"+name) == nil {
+			t.Errorf("expected array keywordTrie%s to be declared", name)
+		}
+	}
+}
+
+func TestGenerateGoTagsPrioritiesAndOptions(t *testing.T) {
+	trie := NewTrieBuilderWithOptions(WithCaseFolding(true), WithMatchKind(MatchNonOverlapping)).
+		AddStringWithTags("he", "pronoun").
+		AddStringWithPriority("hers", 3).
+		Build()
+
+	var buf bytes.Buffer
+	if err := GenerateGo(trie, &buf, "keywords", "keywordTrie"); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), "keywords.go", buf.Bytes(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Scope.Lookup("keywordTrieTags") == nil {
+		t.Error("expected map keywordTrieTags to be declared")
+	}
+
+	if f.Scope.Lookup("keywordTriePriorities") == nil {
+		t.Error("expected map keywordTriePriorities to be declared")
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte(`0: {"pronoun"},`)) {
+		t.Errorf("expected tags of pattern 0 in:\n%s", buf.Bytes())
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte(`1: 3,`)) {
+		t.Errorf("expected priority of pattern 1 in:\n%s", buf.Bytes())
+	}
+
+	options := `.WithOptions(ahocorasick.WithCaseFolding(true), ahocorasick.WithMatchKind(ahocorasick.MatchNonOverlapping))`
+	if !bytes.Contains(buf.Bytes(), []byte(options)) {
+		t.Errorf("expected options in:\n%s", buf.Bytes())
+	}
+}
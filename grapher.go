@@ -6,17 +6,41 @@ import (
 	"os"
 )
 
-// A TrieGrapher is used to output a Trie in the DOT graph description language.
+// A TrieGrapher is used to output a Trie as a graph, by default in the DOT graph description
+// language. Other formats can be selected with Renderer.
 type TrieGrapher struct {
-	trie          *Trie     // The Trie to be graphed.
-	w             io.Writer // A writer to print output to.
-	drawFailLinks bool      // Whether to include fail links in the graph.
+	trie          *Trie         // The Trie to be graphed.
+	w             *errorWriter  // A writer to print output to.
+	renderer      GraphRenderer // Outputs the graph in some format.
+	drawFailLinks bool          // Whether to include fail links in the graph.
+	trace         []*TraceStep  // The steps to highlight (if any).
+	prefix        []byte        // Only graph the subtree under this prefix.
+	maxDepth      int64         // Maximum depth (below the prefix) to graph, if positive.
+	collapse      bool          // Whether to collapse single-child chains.
+	stateSteps    map[int64][]int64
+	edgeSteps     map[graphEdge][]int64
+	visible       map[int64]bool // The states included in the graph.
+}
+
+// A state on the explicit stack used when traversing the Trie.
+type graphFrame struct {
+	s     int64 // The state.
+	depth int64 // The depth of s below the top state.
+	next  int64 // The next symbol to check for a transition.
+	child int64 // The child whose subtree is being graphed (or EmptyCell).
+}
+
+// Identifies an edge for highlighting.
+type graphEdge struct {
+	from, to int64
+	kind     EdgeKind
 }
 
 // Create a new TrieGrapher.
 func NewTrieGrapher(trie *Trie) *TrieGrapher {
 	return &TrieGrapher{
-		trie: trie,
+		trie:     trie,
+		renderer: NewDOTRenderer(),
 	}
 }
 
@@ -26,52 +50,258 @@ func (tg *TrieGrapher) DrawFailLinks(b bool) *TrieGrapher {
 	return tg
 }
 
-// Output the DOT graph to a file.
+// Highlight the path taken through the Trie when matching input: the states visited, the fail
+// links followed and the suffix links used to report matches, labeled with the input positions at
+// which they were visited. Only renderers implementing GraphHighlighter (such as DOTRenderer)
+// support highlighting. A nil input turns highlighting off.
+func (tg *TrieGrapher) Highlight(input []byte) *TrieGrapher {
+	if input == nil {
+		tg.trace = nil
+	} else {
+		tg.trace = tg.trie.Trace(input)
+	}
+	return tg
+}
+
+// Only graph the subtree under prefix (which must be a path in the Trie). Fail and suffix links
+// leaving the subtree are not drawn.
+func (tg *TrieGrapher) Prefix(prefix []byte) *TrieGrapher {
+	tg.prefix = prefix
+	return tg
+}
+
+// Only graph states up to n levels below the root (or prefix). Zero or less means no limit.
+func (tg *TrieGrapher) MaxDepth(n int64) *TrieGrapher {
+	tg.maxDepth = n
+	return tg
+}
+
+// Toggle collapsing of chains of states with a single child (which are not in the dictionary)
+// into one edge, labeling the state at the end of the chain with all the symbols, like in a
+// radix tree. Links to collapsed states are not drawn.
+func (tg *TrieGrapher) CollapseChains(b bool) *TrieGrapher {
+	tg.collapse = b
+	return tg
+}
+
+// Set the renderer used to output the graph (the default is NewDOTRenderer).
+func (tg *TrieGrapher) Renderer(r GraphRenderer) *TrieGrapher {
+	tg.renderer = r
+	return tg
+}
+
+// Output the graph to a file.
 func (tg *TrieGrapher) Graph(path string) error {
 	f, err := os.Create(path)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	tg.w = f
+	if _, err := tg.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Output the graph to w. Returns the number of bytes written and the first error encountered
+// while writing (if any).
+func (tg *TrieGrapher) WriteTo(w io.Writer) (int64, error) {
+	tg.w = &errorWriter{w: w}
 
-	fmt.Fprintln(f, "digraph T {")
-	fmt.Fprintln(f, "\tnodesep=0.2; ranksep=0.4; splines=false; outputorder=edgesfirst;")
-	fmt.Fprintln(f, "\tnode [shape=circle, style=filled, fillcolor=white, fixedsize=true];")
-	fmt.Fprintln(f, "\tedge [arrowsize=0.5];")
+	// Find the top state of the graph.
+	top := RootState
+	for _, b := range tg.prefix {
+		t := tg.trie.base[top] + EncodeByte(b)
+		if t >= int64(len(tg.trie.check)) || tg.trie.check[t] != top {
+			return 0, fmt.Errorf("Prefix %q is not in the trie.", tg.prefix)
+		}
+		top = t
+	}
 
-	// Will recursivelly call graphState on every state (which is in use).
-	tg.graphState(RootState, EmptyCell)
+	tg.collectVisible(top)
+	tg.collectSteps()
 
-	fmt.Fprintln(f, "}")
+	tg.renderer.Begin(tg.w)
+	tg.graphStates(top)
+	tg.renderer.End(tg.w)
 
-	return nil
+	return tg.w.n, tg.w.err
 }
 
-func (tg *TrieGrapher) graphState(s, c int64) {
+// Graph all visible states, using an explicit stack to avoid deep recursion. The order is the
+// same as a recursive depth-first traversal: a state, then the subtree and transition edge of each
+// of its children, and finally its fail and suffix links.
+func (tg *TrieGrapher) graphStates(top int64) {
+	topLabel := ""
+	for _, b := range tg.prefix {
+		topLabel += label(EncodeByte(b))
+	}
+	tg.graphState(top, topLabel)
 
-	if tg.trie.dict[s] != 0 {
-		fmt.Fprintf(tg.w, "\t%d [label=%q, shape=doublecircle];\n", s, label(c))
-	} else {
-		fmt.Fprintf(tg.w, "\t%d [label=%q];\n", s, label(c))
+	stack := []*graphFrame{{s: top, child: EmptyCell}}
+
+	for len(stack) > 0 && tg.w.err == nil {
+		fr := stack[len(stack)-1]
+
+		if fr.child != EmptyCell {
+			tg.graphEdge(fr.s, fr.child, TransitionEdge, true)
+			fr.child = EmptyCell
+		}
+
+		if tg.maxDepth <= 0 || fr.depth < tg.maxDepth {
+			if t, c := tg.nextChild(fr.s, fr.next); t != EmptyCell {
+				t, depth, lbl := tg.follow(t, c, fr.depth+1)
+				fr.next = c + 1
+				fr.child = t
+
+				tg.graphState(t, lbl)
+				stack = append(stack, &graphFrame{s: t, depth: depth, child: EmptyCell})
+				continue
+			}
+		}
+
+		if f := tg.trie.fail[fr.s]; f != EmptyCell && tg.visible[f] {
+			tg.graphEdge(fr.s, f, FailEdge, tg.drawFailLinks && f != RootState)
+		}
+
+		if f := tg.trie.suff[fr.s]; f != EmptyCell && tg.visible[f] {
+			tg.graphEdge(fr.s, f, SuffixEdge, true)
+		}
+
+		stack = stack[:len(stack)-1]
 	}
+}
+
+func (tg *TrieGrapher) graphState(s int64, label string) {
+	tg.renderer.State(tg.w, s, label, tg.trie.dict[s] != 0)
+
+	if hl, ok := tg.renderer.(GraphHighlighter); ok && tg.stateSteps[s] != nil {
+		hl.HighlightState(tg.w, s, tg.stateSteps[s])
+	}
+}
+
+// Collect the states which are included in the graph.
+func (tg *TrieGrapher) collectVisible(top int64) {
+	tg.visible = map[int64]bool{top: true}
+
+	stack := []graphFrame{{s: top}}
+
+	for len(stack) > 0 {
+		fr := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if tg.maxDepth > 0 && fr.depth >= tg.maxDepth {
+			continue
+		}
 
-	for c := int64(0); c < AlphabetSize+1; c++ {
+		for t, c := tg.nextChild(fr.s, 0); t != EmptyCell; t, c = tg.nextChild(fr.s, c+1) {
+			u, depth, _ := tg.follow(t, c, fr.depth+1)
+			tg.visible[u] = true
+			stack = append(stack, graphFrame{s: u, depth: depth})
+		}
+	}
+}
+
+// Find the first transition from s on a symbol >= c. Returns the child and the symbol, or
+// EmptyCell if there are none.
+func (tg *TrieGrapher) nextChild(s, c int64) (int64, int64) {
+	for ; c < AlphabetSize+1; c++ {
 		t := tg.trie.base[s] + c
 		if t < int64(len(tg.trie.check)) && tg.trie.check[t] == s {
-			tg.graphState(t, c)
-			fmt.Fprintf(tg.w, "\t%d -> %d;\n", s, t)
+			return t, c
+		}
+	}
+	return EmptyCell, EmptyCell
+}
+
+// Follow the chain of single children starting at state t (reached on symbol c, at the given
+// depth) if collapsing chains. Returns the last state of the chain, its depth and its label.
+func (tg *TrieGrapher) follow(t, c, depth int64) (int64, int64, string) {
+	lbl := label(c)
+
+	for tg.collapse && tg.trie.dict[t] == 0 && (tg.maxDepth <= 0 || depth < tg.maxDepth) {
+		u, c := tg.nextChild(t, 0)
+		if u == EmptyCell {
+			break
+		}
+		if v, _ := tg.nextChild(t, c+1); v != EmptyCell {
+			break
 		}
+
+		lbl += label(c)
+		t = u
+		depth++
+	}
+
+	return t, depth, lbl
+}
+
+// Output an edge, highlighted if it was visited. Edges which are not visited are only output if
+// draw is set.
+func (tg *TrieGrapher) graphEdge(from, to int64, kind EdgeKind, draw bool) {
+	key := graphEdge{from, to, kind}
+	if kind == TransitionEdge {
+		// Transitions may span a collapsed chain, so look up the last transition in it.
+		key.from = tg.trie.check[to]
+	}
+	steps := tg.edgeSteps[key]
+
+	if hl, ok := tg.renderer.(GraphHighlighter); ok && steps != nil {
+		hl.HighlightEdge(tg.w, from, to, kind, steps)
+	} else if draw {
+		tg.renderer.Edge(tg.w, from, to, kind)
+	}
+}
+
+// Collect the input positions at which each state and edge was visited in the trace.
+func (tg *TrieGrapher) collectSteps() {
+	tg.stateSteps = make(map[int64][]int64)
+	tg.edgeSteps = make(map[graphEdge][]int64)
+
+	addEdge := func(from, to int64, kind EdgeKind, pos int64) {
+		e := graphEdge{from, to, kind}
+		tg.edgeSteps[e] = append(tg.edgeSteps[e], pos)
 	}
 
-	if f := tg.trie.fail[s]; tg.drawFailLinks && f != EmptyCell && f != RootState {
-		fmt.Fprintf(tg.w, "\t%d -> %d [color=red, constraint=false];\n", s, f)
+	for _, step := range tg.trace {
+		tg.stateSteps[step.To] = append(tg.stateSteps[step.To], step.Pos)
+
+		s := step.From
+		for _, f := range step.Fails {
+			addEdge(s, f, FailEdge, step.Pos)
+			s = f
+		}
+
+		if step.To != RootState {
+			addEdge(tg.trie.check[step.To], step.To, TransitionEdge, step.Pos)
+		}
+
+		s = step.To
+		for _, f := range step.Suffs {
+			addEdge(s, f, SuffixEdge, step.Pos)
+			s = f
+		}
 	}
+}
+
+// A writer which counts the bytes written and remembers the first error, after which further
+// writes are ignored.
+type errorWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
 
-	if f := tg.trie.suff[s]; f != EmptyCell {
-		fmt.Fprintf(tg.w, "\t%d -> %d [color=darkgreen, constraint=false];\n", s, f)
+func (ew *errorWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
 	}
+	n, err := ew.w.Write(p)
+	ew.n += int64(n)
+	ew.err = err
+	return n, err
 }
 
 func label(c int64) string {
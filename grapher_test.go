@@ -1,8 +1,13 @@
 package ahocorasick
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
+	"testing"
 )
 
 func ExampleTrieGrapher_Graph() {
@@ -24,3 +29,179 @@ func ExampleTrieGrapher_Graph() {
 		// Output:
 	}
 }
+
+func TestTrieGrapherWriteTo(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"he", "she"}).Build()
+
+	var buf bytes.Buffer
+	n, err := NewTrieGrapher(trie).WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != int64(buf.Len()) {
+		t.Errorf("expected %d bytes written, got %d", buf.Len(), n)
+	}
+
+	for _, line := range []string{"digraph T {\n", "[label=\"e\", shape=doublecircle];\n", "}\n"} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("expected output to contain %q", line)
+		}
+	}
+}
+
+// A writer failing after a given number of bytes.
+type failingWriter struct {
+	n int
+}
+
+func (fw *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > fw.n {
+		n := fw.n
+		fw.n = 0
+		return n, errors.New("write failed")
+	}
+	fw.n -= len(p)
+	return len(p), nil
+}
+
+func TestTrieGrapherWriteToError(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"he", "she"}).Build()
+
+	n, err := NewTrieGrapher(trie).WriteTo(&failingWriter{100})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if n != 100 {
+		t.Errorf("expected %d bytes written, got %d", 100, n)
+	}
+}
+
+func TestTrieGrapherHighlight(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+
+	var buf bytes.Buffer
+	if _, err := NewTrieGrapher(trie).Highlight([]byte("shis")).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	s := trie.step(RootState, EncodeByte('s'))
+	sh := trie.step(s, EncodeByte('h'))
+	h := trie.step(RootState, EncodeByte('h'))
+	hi := trie.step(h, EncodeByte('i'))
+
+	for _, line := range []string{
+		fmt.Sprintf("\t%d [fillcolor=lightblue, xlabel=\"0\"];\n", s),
+		fmt.Sprintf("\t%d -> %d [color=blue, penwidth=2, label=\"2\"];\n", h, hi),
+		fmt.Sprintf("\t%d -> %d [color=red, penwidth=2, constraint=false, label=\"2\"];\n", sh, h),
+	} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, buf.String())
+		}
+	}
+
+	// The transition to hi was highlighted instead of drawn as usual.
+	if strings.Contains(buf.String(), fmt.Sprintf("\t%d -> %d;\n", h, hi)) {
+		t.Errorf("expected transition %d -> %d to only be highlighted", h, hi)
+	}
+}
+
+func TestTrieGrapherHighlightTransform(t *testing.T) {
+	cases := []struct {
+		trie    *Trie
+		pattern string
+		input   string
+		labels  []string // The positions labeling the states along the pattern.
+	}{
+		{NewTrieBuilderWithOptions(WithCaseFolding(true)).AddString("HE").Build(), "HE", "he", []string{"0", "1"}},
+		{NewTrieBuilderWithOptions(WithNormalization(FormNFC)).AddString("é").Build(), "é", "xe\u0301", []string{"1", "1"}},
+	}
+
+	for _, c := range cases {
+		var buf bytes.Buffer
+		if _, err := NewTrieGrapher(c.trie).Highlight([]byte(c.input)).WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		s := RootState
+		for i, b := range c.trie.transform([]byte(c.pattern)) {
+			next := c.trie.step(s, EncodeByte(b))
+			for _, line := range []string{
+				fmt.Sprintf("\t%d [fillcolor=lightblue, xlabel=\"%s\"];\n", next, c.labels[i]),
+				fmt.Sprintf("\t%d -> %d [color=blue, penwidth=2, label=\"%s\"];\n", s, next, c.labels[i]),
+			} {
+				if !strings.Contains(buf.String(), line) {
+					t.Errorf("%q: expected output to contain %q, got:\n%s", c.input, line, buf.String())
+				}
+			}
+			s = next
+		}
+	}
+}
+
+// Graph trie with the JSON renderer, returning the node labels and the number of edges.
+func graphLabels(t *testing.T, tg *TrieGrapher) ([]string, int) {
+	var buf bytes.Buffer
+	if _, err := tg.Renderer(NewJSONRenderer()).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var graph jsonGraph
+	if err := json.Unmarshal(buf.Bytes(), &graph); err != nil {
+		t.Fatal(err)
+	}
+
+	labels := make([]string, len(graph.Nodes))
+	for i, n := range graph.Nodes {
+		labels[i] = n.Label
+	}
+	return labels, len(graph.Edges)
+}
+
+func TestTrieGrapherSubtree(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+
+	cases := []struct {
+		name   string
+		tg     *TrieGrapher
+		labels string
+		edges  int
+	}{
+		{"Prefix", NewTrieGrapher(trie).Prefix([]byte("h")), "h e r s i s", 5},
+		{"MaxDepth", NewTrieGrapher(trie).MaxDepth(2), " h e i s h", 5},
+		{"PrefixMaxDepth", NewTrieGrapher(trie).Prefix([]byte("he")).MaxDepth(1), "he r", 1},
+		{"Collapse", NewTrieGrapher(trie).CollapseChains(true), " h e rs is she", 6},
+		{"CollapseMaxDepth", NewTrieGrapher(trie).CollapseChains(true).MaxDepth(2), " h e i sh", 4},
+	}
+
+	for _, c := range cases {
+		labels, edges := graphLabels(t, c.tg)
+
+		if strings.Join(labels, " ") != c.labels {
+			t.Errorf("%s: expected states %q, got %q", c.name, c.labels, strings.Join(labels, " "))
+		}
+
+		if edges != c.edges {
+			t.Errorf("%s: expected %d edges, got %d", c.name, c.edges, edges)
+		}
+	}
+
+	if _, err := NewTrieGrapher(trie).Prefix([]byte("x")).WriteTo(&bytes.Buffer{}); err == nil {
+		t.Error("expected error for prefix not in trie")
+	}
+}
+
+func TestTrieGrapherDeep(t *testing.T) {
+	trie := NewTrieBuilder().AddString(strings.Repeat("a", 10000)).Build()
+
+	labels, _ := graphLabels(t, NewTrieGrapher(trie))
+	if len(labels) != 10001 {
+		t.Errorf("expected %d states, got %d", 10001, len(labels))
+	}
+
+	labels, _ = graphLabels(t, NewTrieGrapher(trie).CollapseChains(true))
+	if len(labels) != 2 || len(labels[1]) != 10000 {
+		t.Errorf("expected chain to be collapsed, got %d states", len(labels))
+	}
+}
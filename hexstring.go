@@ -0,0 +1,331 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The maximum number of variants the alternations of a HexString may expand to.
+const maxHexVariants = 256
+
+// A HexString is a pattern in the syntax of YARA hex strings, e.g.
+// "{ 4D 5A [2-4] ( 01 02 | 03 ) 4? }", made up of:
+//
+//     4D         matches the byte 0x4D
+//     4? ?D ??   match bytes with the given high nibble, low nibble or any byte
+//     [2-4] [3]  skip any 2 to 4 bytes, or exactly 3 bytes
+//     ( A | B )  matches either A or B, which may contain anything above
+//
+// HexStrings are matched by a WildcardTrie (see WildcardBuilder.AddHexString).
+type HexString struct {
+	items    []hexItem
+	variants [][]wildcardChunk // The alternations expanded, as runs of byte sets between jumps.
+}
+
+// An item of a HexString: a byte (or nibbles) to match, a jump or an alternation.
+type hexItem struct {
+	value, mask byte        // The byte to match, where bits not in mask are wildcards.
+	jump        bool        // Whether the item is a jump.
+	min, max    int64       // The range of a jump.
+	alts        [][]hexItem // The alternatives of an alternation.
+}
+
+// Parse a HexString. The surrounding braces are optional.
+func ParseHexString(s string) (*HexString, error) {
+	p := &hexParser{src: strings.TrimSpace(s)}
+
+	if strings.HasPrefix(p.src, "{") && strings.HasSuffix(p.src, "}") {
+		p.src = p.src[1 : len(p.src)-1]
+	}
+
+	items, err := p.parseSeq(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.skipSpace(); p.pos < len(p.src) {
+		return nil, fmt.Errorf("Unexpected %q at offset %d.", p.src[p.pos], p.pos)
+	}
+
+	h := &HexString{items: items}
+
+	seqs, err := expandHexItems(items)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, seq := range seqs {
+		chunks, err := hexChunks(seq)
+		if err != nil {
+			return nil, err
+		}
+		h.variants = append(h.variants, chunks)
+	}
+
+	return h, nil
+}
+
+type hexParser struct {
+	src string
+	pos int
+}
+
+func (p *hexParser) skipSpace() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// Parse a sequence of items, until the end of input or (inside an alternation) '|' or ')'.
+func (p *hexParser) parseSeq(depth int) ([]hexItem, error) {
+	items := make([]hexItem, 0)
+
+	for p.skipSpace(); p.pos < len(p.src); p.skipSpace() {
+		switch c := p.src[p.pos]; {
+		case c == '|' || c == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("Unexpected %q at offset %d.", c, p.pos)
+			}
+			return items, nil
+		case c == '(':
+			item, err := p.parseAlternation(depth)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		case c == '[':
+			item, err := p.parseJump()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		default:
+			item, err := p.parseByte()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	}
+
+	if depth > 0 {
+		return nil, fmt.Errorf("Unterminated alternation.")
+	}
+
+	return items, nil
+}
+
+func (p *hexParser) parseAlternation(depth int) (hexItem, error) {
+	start := p.pos
+	item := hexItem{}
+
+	for p.pos < len(p.src) && (p.src[p.pos] == '(' || p.src[p.pos] == '|') {
+		p.pos++
+
+		alt, err := p.parseSeq(depth + 1)
+		if err != nil {
+			return item, err
+		}
+		if len(alt) == 0 {
+			return item, fmt.Errorf("Empty alternative in alternation at offset %d.", start)
+		}
+
+		item.alts = append(item.alts, alt)
+
+		if p.src[p.pos] == ')' {
+			p.pos++
+			return item, nil
+		}
+	}
+
+	return item, fmt.Errorf("Unterminated alternation at offset %d.", start)
+}
+
+func (p *hexParser) parseJump() (hexItem, error) {
+	start := p.pos
+
+	end := strings.IndexByte(p.src[p.pos:], ']')
+	if end < 0 {
+		return hexItem{}, fmt.Errorf("Unterminated jump at offset %d.", start)
+	}
+
+	body := strings.Join(strings.Fields(p.src[p.pos+1:p.pos+end]), "")
+	p.pos += end + 1
+
+	lo, hi := body, body
+	if i := strings.IndexByte(body, '-'); i >= 0 {
+		lo, hi = body[:i], body[i+1:]
+	}
+
+	if hi == "" {
+		return hexItem{}, fmt.Errorf("Unbounded jump at offset %d is not supported.", start)
+	}
+
+	min, err1 := strconv.ParseInt(lo, 10, 64)
+	max, err2 := strconv.ParseInt(hi, 10, 64)
+	if err1 != nil || err2 != nil || min < 0 || max < min {
+		return hexItem{}, fmt.Errorf("Invalid jump %q at offset %d.", body, start)
+	}
+
+	return hexItem{jump: true, min: min, max: max}, nil
+}
+
+func (p *hexParser) parseByte() (hexItem, error) {
+	start := p.pos
+	item := hexItem{}
+
+	for i := 0; i < 2; i++ {
+		if p.pos >= len(p.src) {
+			return item, fmt.Errorf("Incomplete byte at offset %d.", start)
+		}
+
+		c := p.src[p.pos]
+		p.pos++
+
+		item.value <<= 4
+		item.mask <<= 4
+
+		if c != '?' {
+			d, ok := hexDigit(c)
+			if !ok {
+				return item, fmt.Errorf("Invalid character %q at offset %d.", c, p.pos-1)
+			}
+			item.value |= d
+			item.mask |= 0xf
+		}
+	}
+
+	return item, nil
+}
+
+// Get the set of bytes matched by a byte item.
+func (item hexItem) set() ByteSet {
+	var set ByteSet
+	for c := 0; c < 256; c++ {
+		if byte(c)&item.mask == item.value {
+			set.Add(byte(c))
+		}
+	}
+	return set
+}
+
+// Expand the alternations in items, returning every sequence of bytes and jumps they can match.
+func expandHexItems(items []hexItem) ([][]hexItem, error) {
+	seqs := [][]hexItem{{}}
+
+	for _, item := range items {
+		if item.alts == nil {
+			for i := range seqs {
+				seqs[i] = append(seqs[i], item)
+			}
+			continue
+		}
+
+		tails := make([][]hexItem, 0)
+		for _, alt := range item.alts {
+			expanded, err := expandHexItems(alt)
+			if err != nil {
+				return nil, err
+			}
+			tails = append(tails, expanded...)
+		}
+
+		if len(seqs)*len(tails) > maxHexVariants {
+			return nil, fmt.Errorf("Alternations expand to more than %d variants.", maxHexVariants)
+		}
+
+		next := make([][]hexItem, 0, len(seqs)*len(tails))
+		for _, seq := range seqs {
+			for _, tail := range tails {
+				variant := make([]hexItem, 0, len(seq)+len(tail))
+				next = append(next, append(append(variant, seq...), tail...))
+			}
+		}
+		seqs = next
+	}
+
+	return seqs, nil
+}
+
+// Convert a sequence of bytes and jumps into runs of byte sets between (merged) jumps.
+func hexChunks(seq []hexItem) ([]wildcardChunk, error) {
+	if len(seq) > 0 && (seq[0].jump || seq[len(seq)-1].jump) {
+		return nil, fmt.Errorf("Hex strings must not start or end with a jump.")
+	}
+
+	chunks := []wildcardChunk{{}}
+
+	for _, item := range seq {
+		last := &chunks[len(chunks)-1]
+
+		switch {
+		case !item.jump:
+			last.sets = append(last.sets, item.set())
+		case len(last.sets) == 0:
+			last.min += item.min // Adjacent jumps are merged.
+			last.max += item.max
+		default:
+			chunks = append(chunks, wildcardChunk{min: item.min, max: item.max})
+		}
+	}
+
+	return chunks, nil
+}
+
+// Format the HexString in the syntax accepted by ParseHexString.
+func (h *HexString) String() string {
+	return "{ " + formatHexItems(h.items) + " }"
+}
+
+func formatHexItems(items []hexItem) string {
+	parts := make([]string, len(items))
+
+	for i, item := range items {
+		switch {
+		case item.alts != nil:
+			alts := make([]string, len(item.alts))
+			for j, alt := range item.alts {
+				alts[j] = formatHexItems(alt)
+			}
+			parts[i] = "( " + strings.Join(alts, " | ") + " )"
+		case item.jump && item.min == item.max:
+			parts[i] = fmt.Sprintf("[%d]", item.min)
+		case item.jump:
+			parts[i] = fmt.Sprintf("[%d-%d]", item.min, item.max)
+		default:
+			parts[i] = formatNibble(item.value>>4, item.mask>>4) + formatNibble(item.value&0xf, item.mask&0xf)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func formatNibble(value, mask byte) string {
+	if mask == 0 {
+		return "?"
+	}
+	return fmt.Sprintf("%X", value)
+}
+
+// Add a HexString to be built into the WildcardTrie. It is given an ID like any other pattern
+// (see AddWildcard). Matches span the whole HexString, choosing the shortest span where jumps
+// allow several.
+func (wb *WildcardBuilder) AddHexString(h *HexString) *WildcardBuilder {
+	for _, chunks := range h.variants {
+		wb.variants = append(wb.variants, wildcardVariant{
+			id:     wb.nextID,
+			chunks: chunks,
+		})
+	}
+	wb.nextID++
+	return wb
+}
+
+// A helper method to make adding multiple HexStrings a little more comfortable.
+func (wb *WildcardBuilder) AddHexStrings(hs []*HexString) *WildcardBuilder {
+	for _, h := range hs {
+		wb.AddHexString(h)
+	}
+	return wb
+}
@@ -0,0 +1,134 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseHexString(t *testing.T) {
+	cases := []struct {
+		input, expected string
+		variants        int
+	}{
+		{"{ 4D 5A }", "{ 4D 5A }", 1},
+		{"4d5a", "{ 4D 5A }", 1},
+		{"{ 4? ?a ?? }", "{ 4? ?A ?? }", 1},
+		{"{ 4D [2-4] 5A [3] 90 }", "{ 4D [2-4] 5A [3] 90 }", 1},
+		{"{ 4D (01 02|03) }", "{ 4D ( 01 02 | 03 ) }", 2},
+		{"{ (01 (02|03)|04) [1] (05|06) }", "{ ( 01 ( 02 | 03 ) | 04 ) [1] ( 05 | 06 ) }", 6},
+		{"{ 01 ( 02 [1] | 03 ) 04 }", "{ 01 ( 02 [1] | 03 ) 04 }", 2},
+	}
+
+	for _, c := range cases {
+		h, err := ParseHexString(c.input)
+		if err != nil {
+			t.Errorf("%q: %v", c.input, err)
+			continue
+		}
+
+		if h.String() != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.input, c.expected, h)
+		}
+
+		if len(h.variants) != c.variants {
+			t.Errorf("%q: expected %d variants, got %d", c.input, c.variants, len(h.variants))
+		}
+	}
+
+	errors := []string{
+		"{ 4 }",
+		"{ 4G }",
+		"{ 4D [2-] 5A }",
+		"{ 4D [4-2] 5A }",
+		"{ 4D [x] 5A }",
+		"{ 4D [2 }",
+		"{ [2] 4D }",
+		"{ 4D [2] }",
+		"{ 4D ( 01 | ) }",
+		"{ 4D ( 01 | 02 }",
+		"{ 4D 01 ) }",
+		"{ (00|01) (00|01) (00|01) (00|01) (00|01) (00|01) (00|01) (00|01) (00|01) }",
+	}
+
+	for _, input := range errors {
+		if _, err := ParseHexString(input); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}
+
+func TestHexChunks(t *testing.T) {
+	h, err := ParseHexString("{ 4D [1] [2-3] 5A 90 [0-1] 00 }")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := make([]string, 0)
+	for _, c := range h.variants[0] {
+		chunks = append(chunks, fmt.Sprintf("[%d-%d] %s", c.min, c.max, c.sets))
+	}
+
+	expected := "[[0-0] 4D [3-4] 5A 90 [0-1] 00]"
+	if got := fmt.Sprint(chunks); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestWildcardTrieHexStrings(t *testing.T) {
+	sources := []string{
+		"{ 4D 5A [2-4] 50 45 }",
+		"{ 50 4B ( 03 04 | 05 06 ) }",
+		"{ 7F 45 4C 46 0? }",
+		"{ 01 [1-2] 41 42 43 }",
+		"{ 41 ( 42 | ?? ) }",
+	}
+
+	hs := make([]*HexString, 0)
+	for _, s := range sources {
+		h, err := ParseHexString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hs = append(hs, h)
+	}
+
+	wt := NewWildcardBuilder().AddHexStrings(hs).Build()
+
+	cases := []struct {
+		input, expected string
+	}{
+		{"MZ..PE", `[{0 "MZ..PE"}]`},
+		{"MZ.....PE", `[]`},
+		{"MZabPEPE", `[{0 "MZabPE"}]`},
+		{"PK\x03\x04 PK\x05\x06 PK\x03\x06", `[{0 "PK\x03\x04"} {5 "PK\x05\x06"}]`},
+		{"\x7fELF\x02 \x7fELF\x12", `[{0 "\x7fELF\x02"}]`},
+		{"\x01\x01xABC", `[{3 "AB"} {1 "\x01xABC"}]`},
+		{"AB", `[{0 "AB"}]`},
+	}
+
+	for _, c := range cases {
+		if got := fmt.Sprint(wt.MatchString(c.input)); got != c.expected {
+			t.Errorf("%q: expected %s, got %s", c.input, c.expected, got)
+		}
+	}
+
+	if matches := wt.MatchString("PK\x05\x06"); len(matches) != 1 || matches[0].ID() != 1 {
+		t.Errorf("expected match of pattern 1, got %v", matches)
+	}
+}
+
+func TestWildcardTrieMixed(t *testing.T) {
+	p, _ := ParseWildcard("41 ?? 43")
+	h, _ := ParseHexString("{ 43 [0-1] 45 }")
+
+	wt := NewWildcardBuilder().AddWildcard(p).AddHexString(h).AddWildcard(p).Build()
+
+	ids := make([]int64, 0)
+	for _, m := range wt.MatchString("ABCDE") {
+		ids = append(ids, m.ID())
+	}
+
+	if fmt.Sprint(ids) != "[0 2 1]" {
+		t.Errorf("expected IDs [0 2 1], got %v", ids)
+	}
+}
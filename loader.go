@@ -3,13 +3,25 @@ package ahocorasick
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 )
 
 const MagicNumber int32 = 0x45495254
 
+// The version of the file format written by SaveTrie. Version 1 files, which have no header, can
+// still be loaded. They come in two layouts: the original one with the arrays base, check, dict,
+// fail and suff, and a later one adding ids, optionally followed by tags and priorities.
+const FormatVersion int64 = 2
+
 // Save a Trie to file.
 func SaveTrie(tr *Trie, path string) error {
+	// Refuse options LoadTrie would refuse, before creating the file.
+	if err := tr.options.validate(); err != nil {
+		return err
+	}
+
 	f, err := os.Create(path)
 	if err != nil {
 		return err
@@ -18,8 +30,13 @@ func SaveTrie(tr *Trie, path string) error {
 
 	binary.Write(f, binary.LittleEndian, MagicNumber)
 
+	// The header starts with the negated version, so it is not mistaken for an array length.
+	if err = writeOptions(f, tr.options); err != nil {
+		return err
+	}
+
 	// Write each of the arrays to the file (preceded by its length).
-	for _, arr := range [][]int64{tr.base, tr.check, tr.dict, tr.fail, tr.suff} {
+	for _, arr := range [][]int64{tr.base, tr.check, tr.dict, tr.fail, tr.suff, tr.ids} {
 		if err = binary.Write(f, binary.LittleEndian, int64(len(arr))); err != nil {
 			return err
 		}
@@ -29,9 +46,69 @@ func SaveTrie(tr *Trie, path string) error {
 		}
 	}
 
+	if err := writeTags(f, tr.tags); err != nil {
+		return err
+	}
+
+	return writePriorities(f, tr.priorities)
+}
+
+// Write the header: the negated format version, followed by the number of option values and the
+// values themselves.
+func writeOptions(w io.Writer, o Options) error {
+	var caseFolding int64
+	if o.CaseFolding {
+		caseFolding = 1
+	}
+
+	values := []int64{-FormatVersion, 3, caseFolding, int64(o.Normalization), int64(o.MatchKind)}
+
+	return binary.Write(w, binary.LittleEndian, values)
+}
+
+// Write the tags of patterns: the number of tagged patterns, followed by the ID, number of tags and
+// tags of each. Strings are preceded by their length.
+func writeTags(w io.Writer, tags map[int64][]string) error {
+	ids := make([]int64, 0, len(tags))
+	for id := range tags {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	values := []interface{}{int64(len(ids))}
+	for _, id := range ids {
+		values = append(values, id, int64(len(tags[id])))
+		for _, tag := range tags[id] {
+			values = append(values, int64(len(tag)), []byte(tag))
+		}
+	}
+
+	for _, v := range values {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// Write the priorities of patterns: the number of patterns with a priority, followed by the ID and
+// priority of each.
+func writePriorities(w io.Writer, priorities map[int64]int64) error {
+	ids := make([]int64, 0, len(priorities))
+	for id := range priorities {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	values := []int64{int64(len(ids))}
+	for _, id := range ids {
+		values = append(values, id, priorities[id])
+	}
+
+	return binary.Write(w, binary.LittleEndian, values)
+}
+
 // Read a Trie from file.
 func LoadTrie(path string) (*Trie, error) {
 	f, err := os.Open(path)
@@ -51,13 +128,48 @@ func LoadTrie(path string) (*Trie, error) {
 
 	tr := new(Trie)
 
-	// Read arrays.
+	// Version 1 files have no header, but start with the length of the first array (in both
+	// layouts).
+	var n int64
+	if err = binary.Read(f, binary.LittleEndian, &n); err != nil {
+		return nil, err
+	}
+
+	headerless := n >= 0
+
+	if !headerless {
+		if -n != FormatVersion {
+			return nil, fmt.Errorf("Unsupported trie file version %d.", -n)
+		}
+
+		if tr.options, err = readOptions(f); err != nil {
+			return nil, err
+		}
 
-	for _, arr := range []*[]int64{&tr.base, &tr.check, &tr.dict, &tr.fail, &tr.suff} {
-		var n int64
 		if err = binary.Read(f, binary.LittleEndian, &n); err != nil {
 			return nil, err
 		}
+	}
+
+	// Read arrays (the length of the first one has been read already).
+
+	for i, arr := range []*[]int64{&tr.base, &tr.check, &tr.dict, &tr.fail, &tr.suff, &tr.ids} {
+		if i > 0 {
+			err = binary.Read(f, binary.LittleEndian, &n)
+
+			// Files in the original format end after the first five arrays, without ids.
+			if err == io.EOF && headerless && arr == &tr.ids {
+				tr.assignIDs()
+				return tr, nil
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("Not a valid trie file (negative array length: %d).", n)
+		}
 
 		*arr = make([]int64, n)
 		if err = binary.Read(f, binary.LittleEndian, arr); err != nil {
@@ -65,5 +177,142 @@ func LoadTrie(path string) (*Trie, error) {
 		}
 	}
 
+	if len(tr.ids) != len(tr.base) {
+		return nil, fmt.Errorf("Not a valid trie file (%d ids for %d cells).", len(tr.ids), len(tr.base))
+	}
+
+	if tr.tags, err = readTags(f); err != nil {
+		return nil, err
+	}
+
+	if tr.priorities, err = readPriorities(f); err != nil {
+		return nil, err
+	}
+
+	tr.transform = tr.options.transform()
+
 	return tr, nil
 }
+
+// Give the patterns of a Trie loaded from a file in the original format, which has no ids, IDs
+// counting from 0 in lexicographic order (the order of Patterns).
+func (tr *Trie) assignIDs() {
+	tr.ids = make([]int64, len(tr.base))
+	for i := range tr.ids {
+		tr.ids[i] = EmptyCell
+	}
+
+	var id int64
+	tr.WalkPrefix(nil, func(pattern []byte, _ int64) bool {
+		tr.ids[tr.walk(pattern)] = id
+		id++
+		return true
+	})
+}
+
+// Read the options of the header written by writeOptions, after the version. Options missing from
+// the file keep their defaults, and extra values are ignored.
+func readOptions(r io.Reader) (Options, error) {
+	var o Options
+
+	var n int64
+	if err := readInts(r, &n); err != nil {
+		return o, err
+	}
+	if n < 0 {
+		return o, fmt.Errorf("Not a valid trie file (negative number of options: %d).", n)
+	}
+
+	values := make([]int64, n)
+	if err := binary.Read(r, binary.LittleEndian, values); err != nil {
+		return o, err
+	}
+
+	for i, v := range values {
+		switch i {
+		case 0:
+			o.CaseFolding = v != 0
+		case 1:
+			o.Normalization = NormalizationForm(v)
+		case 2:
+			o.MatchKind = MatchKind(v)
+		}
+	}
+
+	if err := o.validate(); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// Read the tags of patterns written by writeTags. Files written before tags were supported simply
+// end after the arrays.
+func readTags(r io.Reader) (map[int64][]string, error) {
+	var n int64
+	if err := binary.Read(r, binary.LittleEndian, &n); err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[int64][]string, n)
+
+	for i := int64(0); i < n; i++ {
+		var id, m int64
+		if err := readInts(r, &id, &m); err != nil {
+			return nil, err
+		}
+
+		for j := int64(0); j < m; j++ {
+			var length int64
+			if err := readInts(r, &length); err != nil {
+				return nil, err
+			}
+			if length < 0 {
+				return nil, fmt.Errorf("Not a valid trie file (negative tag length: %d).", length)
+			}
+
+			tag := make([]byte, length)
+			if _, err := io.ReadFull(r, tag); err != nil {
+				return nil, err
+			}
+
+			tags[id] = append(tags[id], string(tag))
+		}
+	}
+
+	return tags, nil
+}
+
+// Read the priorities of patterns written by writePriorities. Files written before priorities were
+// supported simply end after the tags (or the arrays).
+func readPriorities(r io.Reader) (map[int64]int64, error) {
+	var n int64
+	if err := binary.Read(r, binary.LittleEndian, &n); err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	priorities := make(map[int64]int64, n)
+
+	for i := int64(0); i < n; i++ {
+		var id, priority int64
+		if err := readInts(r, &id, &priority); err != nil {
+			return nil, err
+		}
+		priorities[id] = priority
+	}
+
+	return priorities, nil
+}
+
+func readInts(r io.Reader, vs ...*int64) error {
+	for _, v := range vs {
+		if err := binary.Read(r, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
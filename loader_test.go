@@ -1,6 +1,12 @@
 package ahocorasick
 
-import "testing"
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestSaveTrie(t *testing.T) {
 	patterns, err := ReadStrings("./test_data/NSF-ordlisten.cleaned.uniq.txt")
@@ -31,3 +37,127 @@ func TestLoadTrie(t *testing.T) {
 		t.Errorf("expected %d patterns, got %d", 100, trie.NumPatterns())
 	}
 }
+
+func TestSaveTrieTagsAndPriorities(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ahocorasick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "tags.trie")
+
+	trie := NewTrieBuilder().
+		AddStringWithTags("he", "pronoun", "short").
+		AddStringWithPriority("hers", 5).
+		AddStringWithTags("she", "pronoun").
+		Priority(2, -1).
+		Build()
+
+	if err := SaveTrie(trie, path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadTrie(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, expected := fmt.Sprint(loaded.tags), fmt.Sprint(trie.tags); got != expected {
+		t.Errorf("expected tags %s, got %s", expected, got)
+	}
+
+	if got, expected := fmt.Sprint(loaded.priorities), fmt.Sprint(trie.priorities); got != expected {
+		t.Errorf("expected priorities %s, got %s", expected, got)
+	}
+}
+
+func TestLoadTrieOriginalFormat(t *testing.T) {
+	// Written by the original SaveTrie, with the patterns "hers", "his", "he" and "she" and no ids.
+	trie, err := LoadTrie("./test_data/v1-baseline.trie")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := trie.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fmt.Sprintf("%s", trie.Patterns()); got != "[he hers his she]" {
+		t.Errorf("expected patterns [he hers his she], got %s", got)
+	}
+
+	// IDs are given in lexicographic order.
+	ids := make([]int64, 0)
+	for _, m := range trie.MatchString("ushers") {
+		ids = append(ids, m.ID())
+	}
+
+	if got := fmt.Sprint(trie.MatchString("ushers"), ids); got != `[{1 "she"} {2 "he"} {2 "hers"}] [3 0 1]` {
+		t.Errorf("unexpected matches: %s", got)
+	}
+}
+
+func TestLoadTrieHeaderless(t *testing.T) {
+	// Written by SaveTrie before the header was added, with the patterns "hers", "his", "he" and
+	// "she": with ids only, with tags and with tags and priorities.
+	cases := []struct {
+		path       string
+		matches    string
+		tags       string
+		priorities string
+	}{
+		{
+			"./test_data/v1-ids.trie",
+			`[{1 "she"} {2 "he"} {2 "hers"}] [10 2 0]`,
+			"map[]",
+			"map[]",
+		},
+		{
+			"./test_data/v1-tags.trie",
+			`[{1 "she"} {2 "he"} {2 "hers"}] [3 2 0]`,
+			"map[0:[long] 2:[pronoun short] 3:[pronoun]]",
+			"map[]",
+		},
+		{
+			"./test_data/v1-priorities.trie",
+			`[{1 "she"} {2 "he"} {2 "hers"}] [3 2 0]`,
+			"map[0:[long] 2:[pronoun]]",
+			"map[3:5]",
+		},
+	}
+
+	for _, c := range cases {
+		trie, err := LoadTrie(c.path)
+		if err != nil {
+			t.Errorf("%s: %v", c.path, err)
+			continue
+		}
+
+		if err := trie.Verify(); err != nil {
+			t.Errorf("%s: %v", c.path, err)
+		}
+
+		matches := trie.MatchString("ushers")
+		ids := make([]int64, 0)
+		for _, m := range matches {
+			ids = append(ids, m.ID())
+		}
+
+		if got := fmt.Sprint(matches, ids); got != c.matches {
+			t.Errorf("%s: expected matches %s, got %s", c.path, c.matches, got)
+		}
+
+		if got := fmt.Sprint(trie.tags); got != c.tags {
+			t.Errorf("%s: expected tags %s, got %s", c.path, c.tags, got)
+		}
+
+		if got := fmt.Sprint(trie.priorities); got != c.priorities {
+			t.Errorf("%s: expected priorities %s, got %s", c.path, c.priorities, got)
+		}
+
+		if trie.Options() != (Options{}) {
+			t.Errorf("%s: expected default options, got %+v", c.path, trie.Options())
+		}
+	}
+}
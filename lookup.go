@@ -0,0 +1,109 @@
+package ahocorasick
+
+// Check whether pattern is in the dictionary of the Trie.
+func (tr *Trie) Contains(pattern []byte) bool {
+	s := tr.walk(tr.transformPattern(pattern))
+	return s != EmptyCell && tr.dict[s] != 0
+}
+
+// Helper method to make checking for a string pattern a little more comfortable.
+func (tr *Trie) ContainsString(pattern string) bool {
+	return tr.Contains([]byte(pattern))
+}
+
+// Check whether any pattern in the dictionary starts with prefix.
+func (tr *Trie) HasPrefix(prefix []byte) bool {
+	s := tr.walk(tr.transformPattern(prefix))
+
+	if s == RootState {
+		// Every state except an empty root leads to a pattern.
+		for c := int64(1); c <= AlphabetSize; c++ {
+			if t := tr.base[s] + c; t < int64(len(tr.check)) && tr.check[t] == s {
+				return true
+			}
+		}
+		return false
+	}
+
+	return s != EmptyCell
+}
+
+// Helper method to make checking for a string prefix a little more comfortable.
+func (tr *Trie) HasPrefixString(prefix string) bool {
+	return tr.HasPrefix([]byte(prefix))
+}
+
+// Call fn with every pattern (and its ID) starting with prefix, in lexicographic order. The labels
+// are reconstructed from the transitions in the Trie (so they are transformed, if the Trie has a
+// Transform). Stops early if fn returns false.
+func (tr *Trie) WalkPrefix(prefix []byte, fn func(pattern []byte, id int64) bool) {
+	prefix = tr.transformPattern(prefix)
+	top := tr.walk(prefix)
+	if top == EmptyCell {
+		return
+	}
+
+	// A state to visit, reached on symbol c at the given depth below top.
+	type item struct {
+		s, c, depth int64
+	}
+
+	path := append(make([]byte, 0, len(prefix)), prefix...)
+	stack := []item{{top, EmptyCell, 0}}
+
+	for len(stack) > 0 {
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if it.depth > 0 {
+			path = append(path[:len(prefix)+int(it.depth)-1], DecodeByte(it.c))
+		}
+
+		if tr.dict[it.s] != 0 {
+			pattern := append([]byte(nil), path...)
+			if !fn(pattern, tr.ids[it.s]) {
+				return
+			}
+		}
+
+		// Push the children in reverse byte order, so they are popped in lexicographic order.
+		for b := int(AlphabetSize) - 1; b >= 0; b-- {
+			c := EncodeByte(byte(b))
+			if t := tr.base[it.s] + c; t < int64(len(tr.check)) && tr.check[t] == it.s {
+				stack = append(stack, item{t, c, it.depth + 1})
+			}
+		}
+	}
+}
+
+// Get all patterns in the dictionary, in lexicographic order.
+func (tr *Trie) Patterns() [][]byte {
+	patterns := make([][]byte, 0)
+	tr.WalkPrefix(nil, func(pattern []byte, id int64) bool {
+		patterns = append(patterns, pattern)
+		return true
+	})
+	return patterns
+}
+
+// Follow the transitions from the root on the bytes in prefix (without using fail links),
+// returning the state reached or EmptyCell if there is no such path.
+func (tr *Trie) walk(prefix []byte) int64 {
+	s := RootState
+	for _, b := range prefix {
+		t := tr.base[s] + EncodeByte(b)
+		if t >= int64(len(tr.check)) || tr.check[t] != s {
+			return EmptyCell
+		}
+		s = t
+	}
+	return s
+}
+
+// Apply the Transform of the Trie (if any) to a pattern.
+func (tr *Trie) transformPattern(pattern []byte) []byte {
+	if tr.transform == nil {
+		return pattern
+	}
+	return tr.transform(pattern)
+}
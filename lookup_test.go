@@ -0,0 +1,84 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestContains(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she", "\x00\xff"}).Build()
+
+	for _, pattern := range []string{"hers", "his", "he", "she", "\x00\xff"} {
+		if !trie.ContainsString(pattern) {
+			t.Errorf("expected trie to contain %q", pattern)
+		}
+	}
+
+	for _, pattern := range []string{"", "h", "her", "hershey", "sh", "\x00", "x"} {
+		if trie.ContainsString(pattern) {
+			t.Errorf("expected trie not to contain %q", pattern)
+		}
+	}
+}
+
+func TestHasPrefix(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+
+	for _, prefix := range []string{"", "h", "he", "her", "hers", "s", "sh"} {
+		if !trie.HasPrefixString(prefix) {
+			t.Errorf("expected trie to have prefix %q", prefix)
+		}
+	}
+
+	for _, prefix := range []string{"x", "hx", "herself", "shes"} {
+		if trie.HasPrefixString(prefix) {
+			t.Errorf("expected trie not to have prefix %q", prefix)
+		}
+	}
+
+	if NewTrieBuilder().Build().HasPrefixString("") {
+		t.Error("expected empty trie not to have the empty prefix")
+	}
+}
+
+func TestWalkPrefix(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she", "hi", "h\x00", "h\xff"}).Build()
+
+	walk := func(prefix string, limit int) string {
+		patterns := make([]string, 0)
+		trie.WalkPrefix([]byte(prefix), func(pattern []byte, id int64) bool {
+			patterns = append(patterns, fmt.Sprintf("%q:%d", pattern, id))
+			return len(patterns) < limit
+		})
+		return strings.Join(patterns, " ")
+	}
+
+	cases := []struct {
+		prefix   string
+		limit    int
+		expected string
+	}{
+		{"h", 10, `"h\x00":5 "he":2 "hers":0 "hi":4 "his":1 "h\xff":6`},
+		{"he", 10, `"he":2 "hers":0`},
+		{"her", 10, `"hers":0`},
+		{"", 3, `"h\x00":5 "he":2 "hers":0`},
+		{"x", 10, ``},
+	}
+
+	for _, c := range cases {
+		if got := walk(c.prefix, c.limit); got != c.expected {
+			t.Errorf("%q: expected %s, got %s", c.prefix, c.expected, got)
+		}
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	patterns := []string{"she", "hers", "his", "he", "Aho", "a", "\x00"}
+	trie := NewTrieBuilder().AddStrings(patterns).Build()
+
+	expected := `["\x00" "Aho" "a" "he" "hers" "his" "she"]`
+	if got := fmt.Sprintf("%q", trie.Patterns()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
@@ -7,16 +7,23 @@ import (
 
 // Represents a matched pattern.
 type Match struct {
-	pos   int64
-	match []byte
+	pos      int64
+	id       int64
+	match    []byte
+	runePos  int64
+	line     int64
+	column   int64
+	distance int64
+	tags     []string
+	priority int64
 }
 
-func newMatch(pos int64, match []byte) *Match {
-	return &Match{pos, match}
+func newMatch(pos, id int64, match []byte) *Match {
+	return &Match{pos, id, match, EmptyCell, EmptyCell, EmptyCell, 0, nil, 0}
 }
 
 func newMatchString(pos int64, match string) *Match {
-	return &Match{pos, []byte(match)}
+	return &Match{pos, EmptyCell, []byte(match), EmptyCell, EmptyCell, EmptyCell, 0, nil, 0}
 }
 
 func (m *Match) String() string {
@@ -26,10 +33,37 @@ func (m *Match) String() string {
 // Get the position (offset) of the matched pattern.
 func (m *Match) Pos() int64 { return m.pos }
 
+// Get the position of the matched pattern counted in runes (UTF-8 encoded characters) instead of
+// bytes. Only available from a Matcher with RuneOffsets enabled, otherwise -1.
+func (m *Match) RunePos() int64 { return m.runePos }
+
+// Get the line (1-based) on which the matched pattern starts. Only available from a Matcher with
+// Lines enabled, otherwise -1.
+func (m *Match) Line() int64 { return m.line }
+
+// Get the column (1-based) at which the matched pattern starts, counted in bytes (or runes if
+// RuneOffsets is enabled). Only available from a Matcher with Lines enabled, otherwise -1.
+func (m *Match) Column() int64 { return m.column }
+
+// Get the ID of the matched pattern. Unless given explicitly with TrieBuilder.AddPatternWithID,
+// this is the index of the pattern in the order it was added to the TrieBuilder.
+func (m *Match) ID() int64 { return m.id }
+
+// Get the edit distance between the matched bytes and the pattern, which is 0 except for matches
+// from an ApproxTrie.
+func (m *Match) Distance() int64 { return m.distance }
+
+// Get the tags of the matched pattern (see TrieBuilder.AddPatternWithTags).
+func (m *Match) Tags() []string { return m.tags }
+
+// Get the priority of the matched pattern (see TrieBuilder.AddPatternWithPriority), 0 by default.
+func (m *Match) Priority() int64 { return m.priority }
+
 // Get the end position of the matched pattern.
 func (m *Match) End() int64 { return m.pos + int64(len(m.match)) }
 
-// Get the matched byte pattern.
+// Get the matched byte pattern. With a Transform, these are the matched bytes of the original input,
+// which may differ from the pattern.
 func (m *Match) Match() []byte { return m.match }
 
 // Just to make working with strings a little more comfortable.
@@ -0,0 +1,388 @@
+package ahocorasick
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sort"
+	"unicode/utf8"
+)
+
+// The size of the chunks read by MatchReader.
+const readChunkSize = 32 * 1024
+
+// A Matcher runs a Trie against text input, with options for handling UTF-8 encoded text and
+// tracking line numbers. Input can be matched all at once (Match) or streamed (MatchReader).
+type Matcher struct {
+	trie           *Trie // The Trie to match with.
+	maxLen         int64 // Length of the longest pattern in the Trie.
+	runeBoundaries bool  // Whether to reject matches not aligned to rune boundaries.
+	runeOffsets    bool  // Whether to compute rune offsets for matches.
+	lines          bool  // Whether to compute lines and columns for matches.
+	nonOverlapping bool  // Whether to resolve overlapping matches by priority.
+
+	transform Transform // Applied to input before matching, if set.
+}
+
+// The state of a Matcher while matching input, which may arrive in chunks.
+type matchState struct {
+	s  int64   // The current state in the Trie.
+	tp textPos // The current position in the input.
+}
+
+// Create a new Matcher. Its Transform and NonOverlapping options default to those given by the
+// options of the Trie (see NewTrieBuilderWithOptions).
+func NewMatcher(trie *Trie) *Matcher {
+	m := &Matcher{
+		trie:           trie,
+		transform:      trie.transform,
+		nonOverlapping: trie.options.MatchKind == MatchNonOverlapping,
+	}
+
+	for _, n := range trie.dict {
+		if n > m.maxLen {
+			m.maxLen = n
+		}
+	}
+
+	return m
+}
+
+// Toggle rejection of matches which do not both start and end at rune boundaries, that is, matches
+// of (parts of) patterns in the middle of multi-byte UTF-8 encoded characters.
+func (m *Matcher) RuneBoundaries(b bool) *Matcher {
+	m.runeBoundaries = b
+	return m
+}
+
+// Toggle computation of rune offsets, making them available through Match.RunePos. This also makes
+// columns count runes instead of bytes.
+func (m *Matcher) RuneOffsets(b bool) *Matcher {
+	m.runeOffsets = b
+	return m
+}
+
+// Toggle tracking of newlines ('\n'), making line and column numbers of matches available through
+// Match.Line and Match.Column.
+func (m *Matcher) Lines(b bool) *Matcher {
+	m.lines = b
+	return m
+}
+
+// Toggle non-overlapping matching: of matches which overlap, only the one of the pattern with the
+// highest priority (see TrieBuilder.AddPatternWithPriority) is kept, with ties broken by preferring
+// the longest and then the earliest match. Not supported by MatchReader.
+func (m *Matcher) NonOverlapping(b bool) *Matcher {
+	m.nonOverlapping = b
+	return m
+}
+
+// Set a Transform (e.g. NFC) to apply to input before matching, replacing the one of the Trie (see
+// TrieBuilder.Transform). Matches are mapped back to the original input, widened to whole runes
+// with their combining marks. Use nil to match input as is.
+func (m *Matcher) Transform(t Transform) *Matcher {
+	m.transform = t
+	return m
+}
+
+// Run the Trie against the provided input and return matches, according to the options of the
+// Matcher.
+func (m *Matcher) Match(input []byte) []*Match {
+	var matches []*Match
+
+	if m.transform != nil {
+		matches = m.matchTransformed(input)
+	} else {
+		matches = make([]*Match, 0)
+
+		m.feed(m.newMatchState(), input, 0, 0, false, func(match *Match) bool {
+			matches = append(matches, match)
+			return true
+		})
+	}
+
+	if m.nonOverlapping {
+		matches = selectNonOverlapping(matches)
+	}
+
+	return matches
+}
+
+// Helper method to make matching strings a little more comfortable.
+func (m *Matcher) MatchString(input string) []*Match {
+	return m.Match([]byte(input))
+}
+
+// Run the Trie against input read from r, calling fn with each match as soon as it is found.
+// Positions are counted from the start of r. Stops early if fn returns false. Returns the first
+// read error other than io.EOF. Transforms and non-overlapping matching are not supported.
+func (m *Matcher) MatchReader(r io.Reader, fn func(*Match) bool) error {
+	if m.transform != nil {
+		return errors.New("Transforms are not supported when matching a reader.")
+	}
+	if m.nonOverlapping {
+		return errors.New("Non-overlapping matching is not supported when matching a reader.")
+	}
+
+	st := m.newMatchState()
+
+	// The window holds the tail of the previous chunk (enough to hold any match) and the current
+	// chunk. The absolute position of the window is offset.
+	window := make([]byte, 0, readChunkSize+m.maxLen)
+	var offset int64
+
+	for {
+		if keep := int64(len(window)); keep > m.maxLen {
+			offset += keep - m.maxLen
+			window = append(window[:0], window[keep-m.maxLen:]...)
+		}
+
+		from := len(window)
+		n, err := r.Read(window[from : from+readChunkSize])
+		window = window[:from+n]
+
+		if !m.feed(st, window, offset, from, true, fn) {
+			return nil
+		}
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func (m *Matcher) newMatchState() *matchState {
+	st := &matchState{s: RootState}
+
+	if m.lines {
+		st.tp.trackLines(m.maxLen)
+	}
+
+	return st
+}
+
+// Run the Trie against window[from:], where the window starts at position offset in the input.
+// Calls fn with each match, returning false if it did.
+func (m *Matcher) feed(st *matchState, window []byte, offset int64, from int, copyMatches bool,
+	fn func(*Match) bool) bool {
+
+	tr := m.trie
+
+	for i := from; i < len(window); i++ {
+		c := window[i]
+
+		st.s = tr.step(st.s, EncodeByte(c))
+		st.tp.advance(c)
+
+		end := int64(i + 1)
+
+		for f := st.s; f != EmptyCell; f = tr.suff[f] {
+			if tr.dict[f] == 0 {
+				continue // Only the first state may not be in the dictionary.
+			}
+
+			b := window[end-tr.dict[f] : end]
+			if copyMatches {
+				b = append([]byte(nil), b...)
+			}
+
+			if match := m.newMatch(b, offset+end, tr.ids[f], &st.tp); match != nil && !fn(match) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Create the match of b ending at end, unless it is rejected by the options.
+func (m *Matcher) newMatch(b []byte, end, id int64, tp *textPos) *Match {
+	if m.runeBoundaries && !(tp.atBoundary() && utf8.RuneStart(b[0])) {
+		return nil
+	}
+
+	match := m.trie.newMatch(end-int64(len(b)), id, b)
+
+	if m.runeOffsets {
+		match.runePos = tp.runes - runeStarts(b)
+	}
+
+	if m.lines {
+		match.line = tp.line - int64(bytes.Count(b, []byte{'\n'}))
+		m.setColumn(match, tp.lineStart(match.line))
+	}
+
+	return match
+}
+
+func (m *Matcher) setColumn(match *Match, start textStart) {
+	if m.runeOffsets {
+		match.column = match.runePos - start.runes + 1
+	} else {
+		match.column = match.pos - start.bytes + 1
+	}
+}
+
+// Match the transformed input, mapping matches back to the original input.
+func (m *Matcher) matchTransformed(input []byte) []*Match {
+	tf := transformInput(m.transform, input)
+	matches := make([]*Match, 0)
+
+	// Rune offsets and lines refer to the original input, so they are located afterwards.
+	inner := &Matcher{
+		trie:           m.trie,
+		runeBoundaries: m.runeBoundaries,
+	}
+
+	inner.feed(inner.newMatchState(), tf.out, 0, 0, false, func(match *Match) bool {
+		pos, end := tf.original(match.pos, match.End())
+		match.pos = pos
+		match.match = input[pos:end]
+		matches = append(matches, match)
+		return true
+	})
+
+	if m.runeOffsets || m.lines {
+		m.locate(input, matches)
+	}
+
+	return matches
+}
+
+// Compute the rune offsets and lines of matches in input, in one pass over the input.
+func (m *Matcher) locate(input []byte, matches []*Match) {
+	sorted := append([]*Match(nil), matches...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].pos < sorted[j].pos
+	})
+
+	var tp textPos
+	tp.trackLines(0)
+
+	for _, match := range sorted {
+		for tp.bytes < match.pos {
+			tp.advance(input[tp.bytes])
+		}
+
+		if m.runeOffsets {
+			match.runePos = tp.runes
+		}
+
+		if m.lines {
+			match.line = tp.line
+			m.setColumn(match, tp.lineStart(tp.line))
+		}
+	}
+}
+
+// Select matches which do not overlap, considering them in order of priority, length and position
+// (and ID, for matches which are otherwise equal). Returns the selected matches ordered by position.
+func selectNonOverlapping(matches []*Match) []*Match {
+	candidates := append([]*Match(nil), matches...)
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		switch {
+		case a.priority != b.priority:
+			return a.priority > b.priority
+		case len(a.match) != len(b.match):
+			return len(a.match) > len(b.match)
+		case a.pos != b.pos:
+			return a.pos < b.pos
+		}
+		return a.id < b.id
+	})
+
+	// The selected matches, ordered by position. As they do not overlap, a candidate only needs to
+	// be checked against its neighbours.
+	selected := make([]*Match, 0)
+
+	for _, c := range candidates {
+		i := sort.Search(len(selected), func(i int) bool {
+			return selected[i].pos >= c.pos
+		})
+
+		if i > 0 && selected[i-1].End() > c.pos {
+			continue
+		}
+		if i < len(selected) && (selected[i].pos < c.End() || selected[i].pos == c.pos) {
+			continue
+		}
+
+		selected = append(selected, nil)
+		copy(selected[i+1:], selected[i:])
+		selected[i] = c
+	}
+
+	return selected
+}
+
+// Tracks the position in UTF-8 encoded text, one byte at a time. Every byte which is not a
+// continuation byte is counted as the start of a rune, which for invalid UTF-8 may differ slightly
+// from utf8.RuneCount.
+type textPos struct {
+	bytes   int64       // Number of bytes seen.
+	runes   int64       // Number of runes started.
+	pending int         // Number of continuation bytes expected to complete the current rune.
+	line    int64       // The current line (1-based), if tracking lines.
+	starts  []textStart // The starts of the most recent lines, indexed by line modulo length.
+}
+
+// The start of a line.
+type textStart struct {
+	bytes, runes int64
+}
+
+// Start tracking lines, remembering the starts of the last n+1 lines.
+func (tp *textPos) trackLines(n int64) {
+	tp.line = 1
+	tp.starts = make([]textStart, n+1)
+}
+
+func (tp *textPos) advance(b byte) {
+	tp.bytes++
+
+	switch {
+	case !utf8.RuneStart(b):
+		if tp.pending > 0 {
+			tp.pending--
+		}
+		return
+	case b < 0xc0:
+		tp.pending = 0
+	case b < 0xe0:
+		tp.pending = 1
+	case b < 0xf0:
+		tp.pending = 2
+	default:
+		tp.pending = 3
+	}
+	tp.runes++
+
+	if b == '\n' && tp.starts != nil {
+		tp.line++
+		tp.starts[tp.line%int64(len(tp.starts))] = textStart{tp.bytes, tp.runes}
+	}
+}
+
+// Check whether the last byte completed a rune.
+func (tp *textPos) atBoundary() bool {
+	return tp.pending == 0
+}
+
+// Get the start of one of the most recent lines.
+func (tp *textPos) lineStart(line int64) textStart {
+	return tp.starts[line%int64(len(tp.starts))]
+}
+
+// Count the bytes in b which start a rune.
+func runeStarts(b []byte) int64 {
+	var n int64
+	for _, c := range b {
+		if utf8.RuneStart(c) {
+			n++
+		}
+	}
+	return n
+}
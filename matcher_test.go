@@ -0,0 +1,206 @@
+package ahocorasick
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestMatcherRuneBoundaries(t *testing.T) {
+	// "é" is "\xc3\xa9" and "€" is "\xe2\x82\xac" in UTF-8.
+	trie := NewTrieBuilder().
+		AddStrings([]string{"\xa9", "\xc3", "é", "\x82\xac", "€", "a"}).
+		Build()
+
+	input := "café 10€ a"
+
+	cases := []struct {
+		name     string
+		matcher  *Matcher
+		expected string
+	}{
+		{
+			"Bytes",
+			NewMatcher(trie),
+			`[{1 "a"} {3 "\xc3"} {3 "é"} {4 "\xa9"} {8 "€"} {9 "\x82\xac"} {12 "a"}]`,
+		},
+		{
+			"Runes",
+			NewMatcher(trie).RuneBoundaries(true),
+			`[{1 "a"} {3 "é"} {8 "€"} {12 "a"}]`,
+		},
+	}
+
+	for _, c := range cases {
+		if got := fmt.Sprint(c.matcher.MatchString(input)); got != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestMatcherRuneOffsets(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"é", "€", "a", "10"}).Build()
+	input := "café 10€ a"
+
+	matches := NewMatcher(trie).RuneOffsets(true).MatchString(input)
+	expected := []int64{1, 3, 5, 7, 9}
+
+	if len(matches) != len(expected) {
+		t.Fatalf("expected %d matches, got %d", len(expected), len(matches))
+	}
+
+	for i, m := range matches {
+		if m.RunePos() != expected[i] {
+			t.Errorf("%v: expected rune offset %d, got %d", m, expected[i], m.RunePos())
+		}
+	}
+
+	if m := NewMatcher(trie).MatchString(input)[0]; m.RunePos() != -1 {
+		t.Errorf("expected no rune offset, got %d", m.RunePos())
+	}
+}
+
+func TestMatcherLines(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"he", "é", "\nhe", "he\n\n"}).Build()
+	input := "he\nthé he\n\nhe\n"
+
+	cases := []struct {
+		name     string
+		matcher  *Matcher
+		expected string
+	}{
+		{"Bytes", NewMatcher(trie).Lines(true), "1:1 2:3 2:6 2:6 3:1 4:1"},
+		{"Runes", NewMatcher(trie).Lines(true).RuneOffsets(true), "1:1 2:3 2:5 2:5 3:1 4:1"},
+	}
+
+	for _, c := range cases {
+		positions := make([]string, 0)
+		for _, m := range c.matcher.MatchString(input) {
+			positions = append(positions, fmt.Sprintf("%d:%d", m.Line(), m.Column()))
+		}
+
+		if got := strings.Join(positions, " "); got != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestMatcherMatchReader(t *testing.T) {
+	patterns, err := ReadStrings("./test_data/opphavsrett.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input, err := ioutil.ReadFile("./test_data/Ibsen.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	words := make([][]byte, 0)
+	for _, p := range patterns {
+		words = append(words, bytes.Fields(p)...)
+	}
+
+	trie := NewTrieBuilder().AddPatterns(words).AddString("\r\n").Build()
+	matcher := NewMatcher(trie).RuneBoundaries(true).RuneOffsets(true).Lines(true)
+
+	expected := matcher.Match(input)
+	if len(expected) < 1000 {
+		t.Fatalf("expected more matches, got %d", len(expected))
+	}
+
+	// Read in pieces of different sizes to exercise the window handling.
+	cases := []struct {
+		r        io.Reader
+		expected []*Match
+	}{
+		{bytes.NewReader(input), expected},
+		{iotest.HalfReader(bytes.NewReader(input)), expected},
+		{iotest.OneByteReader(bytes.NewReader(input[:5000])), matcher.Match(input[:5000])},
+	}
+
+	for _, c := range cases {
+		matches := make([]*Match, 0)
+		err = matcher.MatchReader(c.r, func(m *Match) bool {
+			matches = append(matches, m)
+			return true
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(matches) != len(c.expected) {
+			t.Fatalf("expected %d matches, got %d", len(c.expected), len(matches))
+		}
+
+		for i, m := range matches {
+			e := c.expected[i]
+			if !MatchEqual(m, e) || m.RunePos() != e.RunePos() || m.Line() != e.Line() || m.Column() != e.Column() {
+				t.Fatalf("expected %v (%d %d:%d), got %v (%d %d:%d)",
+					e, e.RunePos(), e.Line(), e.Column(), m, m.RunePos(), m.Line(), m.Column())
+			}
+		}
+	}
+
+	// Stop early.
+	n := 0
+	err = matcher.MatchReader(bytes.NewReader(input), func(m *Match) bool {
+		n++
+		return n < 10
+	})
+	if err != nil || n != 10 {
+		t.Errorf("expected to stop after %d matches, got %d (%v)", 10, n, err)
+	}
+}
+
+func TestMatcherNonOverlapping(t *testing.T) {
+	cases := []struct {
+		name     string
+		trie     *Trie
+		input    string
+		expected string
+	}{
+		{
+			"Length",
+			NewTrieBuilder().AddStrings([]string{"us", "she", "he", "hers"}).Build(),
+			"ushers",
+			`[{0 "us"} {2 "hers"}]`,
+		},
+		{
+			"Priority",
+			NewTrieBuilder().AddStrings([]string{"us", "he", "hers"}).AddStringWithPriority("she", 2).Build(),
+			"ushers",
+			`[{1 "she"}]`,
+		},
+		{
+			"Negative priority",
+			NewTrieBuilder().AddStringWithPriority("hers", -1).AddStrings([]string{"she", "rs"}).Build(),
+			"ushers",
+			`[{1 "she"} {4 "rs"}]`,
+		},
+		{
+			"Position",
+			NewTrieBuilder().AddStrings([]string{"aa", "b"}).Build(),
+			"aaab",
+			`[{0 "aa"} {3 "b"}]`,
+		},
+	}
+
+	for _, c := range cases {
+		if got := fmt.Sprint(NewMatcher(c.trie).NonOverlapping(true).MatchString(c.input)); got != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, got)
+		}
+	}
+
+	trie := NewTrieBuilder().AddString("he").Build()
+	err := NewMatcher(trie).NonOverlapping(true).MatchReader(strings.NewReader("he"), func(*Match) bool {
+		return true
+	})
+	if err == nil {
+		t.Error("expected error when matching a reader")
+	}
+}
@@ -0,0 +1,243 @@
+package ahocorasick
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A Transform maps text to the form in which it is matched, e.g. a Unicode normalization form (see
+// TrieBuilder.Transform and Matcher.Transform).
+//
+// Input is transformed one segment at a time, where a segment is a rune followed by its combining
+// marks, so that matches in the transformed input can be mapped back to the original input. A
+// Transform should therefore map each segment independently of the surrounding text.
+//
+// NFC, NFKC and FoldCase are provided, but any function of this type can be used, e.g.
+// bytes.ToLower or the Bytes methods of the forms in golang.org/x/text/unicode/norm (for complete
+// Unicode coverage).
+type Transform func([]byte) []byte
+
+// Compositions of two characters into one, the inverse of the canonical decompositions.
+var compositions = make(map[[2]rune]rune)
+
+func init() {
+	excluded := make(map[rune]bool)
+	for _, r := range compositionExclusions {
+		excluded[r] = true
+	}
+
+	for r, d := range canonicalDecompositions {
+		if len(d) == 2 && !excluded[r] {
+			compositions[[2]rune{d[0], d[1]}] = r
+		}
+	}
+}
+
+// Transform UTF-8 encoded text to Unicode Normalization Form C (canonical composition), e.g.
+// "cafe\u0301" to "café". Only the characters in the local tables are normalized: Latin,
+// Greek and Cyrillic letters with diacritics. Invalid UTF-8 is left as is.
+func NFC(b []byte) []byte {
+	return normalize(b, false)
+}
+
+// Transform UTF-8 encoded text to Unicode Normalization Form KC (compatibility composition). In
+// addition to NFC, this maps compatibility characters such as ligatures, super- and subscripts,
+// fullwidth forms and special spaces to their plain equivalents, e.g. "ﬁ" to "fi".
+func NFKC(b []byte) []byte {
+	return normalize(b, true)
+}
+
+// Transform UTF-8 encoded text for case-insensitive matching, using simple Unicode case folding:
+// each rune is mapped to the smallest rune it folds to, e.g. both "Hello" and "hELLO" to "HELLO".
+// Invalid UTF-8 is left as is.
+func FoldCase(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size == 1 {
+			out = append(out, b[0])
+		} else {
+			out = append(out, string(foldRune(r))...)
+		}
+		b = b[size:]
+	}
+
+	return out
+}
+
+// Get the smallest rune in the case folding orbit of r.
+func foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+func normalize(b []byte, compat bool) []byte {
+	out := make([]byte, 0, len(b))
+	var rs []rune
+
+	for len(b) > 0 {
+		n := segmentLen(b)
+
+		if r, size := utf8.DecodeRune(b); r == utf8.RuneError && size == 1 {
+			out = append(out, b[0]) // Invalid UTF-8 is a segment of its own.
+		} else {
+			rs = rs[:0]
+			for _, r := range string(b[:n]) {
+				rs = decompose(rs, r, compat)
+			}
+
+			for _, r := range compose(sortMarks(rs)) {
+				out = utf8.AppendRune(out, r)
+			}
+		}
+
+		b = b[n:]
+	}
+
+	return out
+}
+
+// Append the full decomposition of r to rs.
+func decompose(rs []rune, r rune, compat bool) []rune {
+	d, ok := canonicalDecompositions[r]
+	if !ok && compat {
+		d, ok = compatibilityDecompositions[r]
+	}
+
+	if !ok {
+		return append(rs, r)
+	}
+
+	for _, r := range d {
+		rs = decompose(rs, r, compat)
+	}
+
+	return rs
+}
+
+// Put each run of combining marks in rs in canonical order, that is, sorted by combining class.
+func sortMarks(rs []rune) []rune {
+	for i := 0; i < len(rs); {
+		if combiningClasses[rs[i]] == 0 {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(rs) && combiningClasses[rs[j]] != 0 {
+			j++
+		}
+
+		marks := rs[i:j]
+		sort.SliceStable(marks, func(a, b int) bool {
+			return combiningClasses[marks[a]] < combiningClasses[marks[b]]
+		})
+
+		i = j
+	}
+
+	return rs
+}
+
+// Compose the (decomposed and sorted) runes in rs in place, following the canonical composition
+// algorithm: a character is combined with the last starter unless blocked by a character in
+// between with the same or higher combining class.
+func compose(rs []rune) []rune {
+	out := rs[:0]
+	starter := -1 // Index of the last starter in out.
+	var last int  // Combining class of the last rune in out after the starter, or -1 if none.
+
+	for _, r := range rs {
+		class := int(combiningClasses[r])
+
+		if starter >= 0 && (last == -1 || (last != 0 && last < class)) {
+			if c, ok := compositions[[2]rune{out[starter], r}]; ok {
+				out[starter] = c
+				continue
+			}
+		}
+
+		if class == 0 {
+			starter = len(out)
+			last = -1
+		} else {
+			last = class
+		}
+
+		out = append(out, r)
+	}
+
+	return out
+}
+
+// Get the length of the segment at the start of b: one rune (or one byte of invalid UTF-8) along
+// with any combining marks following it.
+func segmentLen(b []byte) int {
+	r, n := utf8.DecodeRune(b)
+	if r == utf8.RuneError && n == 1 {
+		return 1
+	}
+
+	for n < len(b) {
+		r, size := utf8.DecodeRune(b[n:])
+		if !isMark(r) {
+			break
+		}
+		n += size
+	}
+
+	return n
+}
+
+func isMark(r rune) bool {
+	return combiningClasses[r] != 0 || unicode.Is(unicode.M, r)
+}
+
+// Input transformed segment by segment, along with the starts of the segments in both the
+// transformed and the original input, which allows mapping positions back to the original input.
+type transformed struct {
+	out  []byte  // The transformed input.
+	outs []int64 // Start of each segment in out, followed by len(out).
+	ins  []int64 // Start of each segment in the original input, followed by its length.
+}
+
+func transformInput(t Transform, input []byte) *transformed {
+	tf := &transformed{
+		out:  make([]byte, 0, len(input)),
+		outs: make([]int64, 0, len(input)+1),
+		ins:  make([]int64, 0, len(input)+1),
+	}
+
+	for i := 0; i < len(input); {
+		n := segmentLen(input[i:])
+
+		tf.outs = append(tf.outs, int64(len(tf.out)))
+		tf.ins = append(tf.ins, int64(i))
+		tf.out = append(tf.out, t(input[i:i+n])...)
+
+		i += n
+	}
+
+	tf.outs = append(tf.outs, int64(len(tf.out)))
+	tf.ins = append(tf.ins, int64(len(input)))
+
+	return tf
+}
+
+// Map the positions of a match in the transformed input to the original input. The match is
+// widened to the segments it overlaps.
+func (tf *transformed) original(pos, end int64) (int64, int64) {
+	return tf.ins[tf.segment(pos)], tf.ins[tf.segment(end-1)+1]
+}
+
+// Get the index of the segment containing the byte at pos in the transformed input.
+func (tf *transformed) segment(pos int64) int {
+	return sort.Search(len(tf.outs), func(i int) bool { return tf.outs[i] > pos }) - 1
+}
@@ -0,0 +1,144 @@
+package ahocorasick
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNFC(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"caf\u00e9", "caf\u00e9"},
+		{"cafe\u0301", "caf\u00e9"},
+		{"A\u030a", "\u00c5"},
+		{"\u212b", "\u00c5"}, // Angstrom sign.
+		{"a\u0323\u0302", "\u1ead"},
+		{"a\u0302\u0323", "\u1ead"}, // Marks in non-canonical order.
+		{"u\u0308\u0304", "\u01d6"},
+		{"\u0438\u0306", "\u0439"},
+		{"\u0301e", "\u0301e"}, // Lone combining mark.
+		{"a\xff\u0301", "a\xff\u0301"},
+		{"\ufb01", "\ufb01"},
+	}
+
+	for _, c := range cases {
+		if got := string(NFC([]byte(c.input))); got != c.expected {
+			t.Errorf("NFC(%+q): expected %+q, got %+q", c.input, c.expected, got)
+		}
+	}
+}
+
+func TestNFKC(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"cafe\u0301", "caf\u00e9"},
+		{"\ufb01", "fi"},
+		{"\ufb01\u0301", "f\u00ed"},
+		{"\uff21\uff22\uff23", "ABC"},
+		{"x\u00b2", "x2"},
+		{"a\u00a0b", "a b"},
+		{"\u00bd", "1\u20442"},
+		{"\u2126", "\u03a9"}, // Ohm sign.
+	}
+
+	for _, c := range cases {
+		if got := string(NFKC([]byte(c.input))); got != c.expected {
+			t.Errorf("NFKC(%+q): expected %+q, got %+q", c.input, c.expected, got)
+		}
+	}
+}
+
+func TestTransformMatch(t *testing.T) {
+	trie := NewTrieBuilder().Transform(NFC).AddStrings([]string{"cafe\u0301", "\u00e9t"}).Build()
+	input := "un cafe\u0301 et un caf\u00e9 e\u0301te\u0301"
+
+	expected := "{3 cafe\u0301} {16 caf\u00e9} {22 e\u0301t}"
+	if got := matchStrings(trie.MatchString(input)); got != expected {
+		t.Errorf("expected %+q, got %+q", expected, got)
+	}
+
+	if n := trie.CountString(input); n != 3 {
+		t.Errorf("expected 3 matches, got %d", n)
+	}
+
+	if m := trie.MatchStringFirst(input); m == nil || m.Pos() != 3 {
+		t.Errorf("expected first match at 3, got %v", m)
+	}
+
+	if !trie.ContainsString("caf\u00e9") || !trie.HasPrefixString("e\u0301") {
+		t.Error("expected lookups to be transformed")
+	}
+
+	if matches := NewMatcher(trie).Transform(nil).MatchString(input); len(matches) != 1 {
+		t.Errorf("expected 1 match without transform, got %v", matches)
+	}
+}
+
+// Format matches like "{pos match} {pos match}", without quoting.
+func matchStrings(matches []*Match) string {
+	s := make([]string, len(matches))
+	for i, m := range matches {
+		s[i] = fmt.Sprintf("{%d %s}", m.Pos(), m.Match())
+	}
+	return strings.Join(s, " ")
+}
+
+func TestTransformMatchPositions(t *testing.T) {
+	trie := NewTrieBuilder().Transform(NFKC).AddStrings([]string{"fin", "ABC"}).Build()
+	input := "\ufb01n\nx \uff21\uff22\uff23 \ufb01n"
+
+	matches := NewMatcher(trie).RuneOffsets(true).Lines(true).MatchString(input)
+
+	expected := []struct {
+		pos, runePos, line, column int64
+		match                      string
+	}{
+		{0, 0, 1, 1, "\ufb01n"},
+		{7, 5, 2, 3, "\uff21\uff22\uff23"},
+		{17, 9, 2, 7, "\ufb01n"},
+	}
+
+	if len(matches) != len(expected) {
+		t.Fatalf("expected %d matches, got %v", len(expected), matches)
+	}
+
+	for i, m := range matches {
+		e := expected[i]
+		if m.Pos() != e.pos || m.RunePos() != e.runePos || m.Line() != e.line ||
+			m.Column() != e.column || m.MatchString() != e.match {
+			t.Errorf("expected %+v, got %v (rune %d, %d:%d)", e, m, m.RunePos(), m.Line(), m.Column())
+		}
+	}
+}
+
+func TestTransformPartialSegment(t *testing.T) {
+	// A match of part of a transformed segment is widened to the whole segment.
+	trie := NewTrieBuilder().Transform(NFKC).AddString("f").Build()
+
+	matches := trie.MatchString("a\ufb01")
+	if len(matches) != 1 || matches[0].Pos() != 1 || matches[0].MatchString() != "\ufb01" {
+		t.Errorf("expected match of %+q at 1, got %v", "\ufb01", matches)
+	}
+}
+
+func TestCustomTransform(t *testing.T) {
+	trie := NewTrieBuilder().Transform(bytes.ToLower).AddStrings([]string{"Hers", "SHE"}).Build()
+
+	expected := `[{0 "HERS"} {5 "She"}]`
+	if got := fmt.Sprint(trie.MatchString("HERS She")); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestTransformMatchReader(t *testing.T) {
+	trie := NewTrieBuilder().Transform(NFC).AddString("café").Build()
+
+	err := NewMatcher(trie).MatchReader(strings.NewReader("café"), func(*Match) bool { return true })
+	if err == nil {
+		t.Error("expected error when matching a reader with a transform")
+	}
+}
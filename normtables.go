@@ -0,0 +1,1527 @@
+package ahocorasick
+
+// The tables in this file are derived from the Unicode Character Database (version 14.0.0). They
+// cover Latin, Greek and Cyrillic letters with diacritics, and common compatibility characters such
+// as ligatures, super- and subscripts, and fullwidth forms.
+
+// Canonical decompositions, one level deep.
+var canonicalDecompositions = map[rune][]rune{
+	0x00C0: {0x0041, 0x0300},
+	0x00C1: {0x0041, 0x0301},
+	0x00C2: {0x0041, 0x0302},
+	0x00C3: {0x0041, 0x0303},
+	0x00C4: {0x0041, 0x0308},
+	0x00C5: {0x0041, 0x030A},
+	0x00C7: {0x0043, 0x0327},
+	0x00C8: {0x0045, 0x0300},
+	0x00C9: {0x0045, 0x0301},
+	0x00CA: {0x0045, 0x0302},
+	0x00CB: {0x0045, 0x0308},
+	0x00CC: {0x0049, 0x0300},
+	0x00CD: {0x0049, 0x0301},
+	0x00CE: {0x0049, 0x0302},
+	0x00CF: {0x0049, 0x0308},
+	0x00D1: {0x004E, 0x0303},
+	0x00D2: {0x004F, 0x0300},
+	0x00D3: {0x004F, 0x0301},
+	0x00D4: {0x004F, 0x0302},
+	0x00D5: {0x004F, 0x0303},
+	0x00D6: {0x004F, 0x0308},
+	0x00D9: {0x0055, 0x0300},
+	0x00DA: {0x0055, 0x0301},
+	0x00DB: {0x0055, 0x0302},
+	0x00DC: {0x0055, 0x0308},
+	0x00DD: {0x0059, 0x0301},
+	0x00E0: {0x0061, 0x0300},
+	0x00E1: {0x0061, 0x0301},
+	0x00E2: {0x0061, 0x0302},
+	0x00E3: {0x0061, 0x0303},
+	0x00E4: {0x0061, 0x0308},
+	0x00E5: {0x0061, 0x030A},
+	0x00E7: {0x0063, 0x0327},
+	0x00E8: {0x0065, 0x0300},
+	0x00E9: {0x0065, 0x0301},
+	0x00EA: {0x0065, 0x0302},
+	0x00EB: {0x0065, 0x0308},
+	0x00EC: {0x0069, 0x0300},
+	0x00ED: {0x0069, 0x0301},
+	0x00EE: {0x0069, 0x0302},
+	0x00EF: {0x0069, 0x0308},
+	0x00F1: {0x006E, 0x0303},
+	0x00F2: {0x006F, 0x0300},
+	0x00F3: {0x006F, 0x0301},
+	0x00F4: {0x006F, 0x0302},
+	0x00F5: {0x006F, 0x0303},
+	0x00F6: {0x006F, 0x0308},
+	0x00F9: {0x0075, 0x0300},
+	0x00FA: {0x0075, 0x0301},
+	0x00FB: {0x0075, 0x0302},
+	0x00FC: {0x0075, 0x0308},
+	0x00FD: {0x0079, 0x0301},
+	0x00FF: {0x0079, 0x0308},
+	0x0100: {0x0041, 0x0304},
+	0x0101: {0x0061, 0x0304},
+	0x0102: {0x0041, 0x0306},
+	0x0103: {0x0061, 0x0306},
+	0x0104: {0x0041, 0x0328},
+	0x0105: {0x0061, 0x0328},
+	0x0106: {0x0043, 0x0301},
+	0x0107: {0x0063, 0x0301},
+	0x0108: {0x0043, 0x0302},
+	0x0109: {0x0063, 0x0302},
+	0x010A: {0x0043, 0x0307},
+	0x010B: {0x0063, 0x0307},
+	0x010C: {0x0043, 0x030C},
+	0x010D: {0x0063, 0x030C},
+	0x010E: {0x0044, 0x030C},
+	0x010F: {0x0064, 0x030C},
+	0x0112: {0x0045, 0x0304},
+	0x0113: {0x0065, 0x0304},
+	0x0114: {0x0045, 0x0306},
+	0x0115: {0x0065, 0x0306},
+	0x0116: {0x0045, 0x0307},
+	0x0117: {0x0065, 0x0307},
+	0x0118: {0x0045, 0x0328},
+	0x0119: {0x0065, 0x0328},
+	0x011A: {0x0045, 0x030C},
+	0x011B: {0x0065, 0x030C},
+	0x011C: {0x0047, 0x0302},
+	0x011D: {0x0067, 0x0302},
+	0x011E: {0x0047, 0x0306},
+	0x011F: {0x0067, 0x0306},
+	0x0120: {0x0047, 0x0307},
+	0x0121: {0x0067, 0x0307},
+	0x0122: {0x0047, 0x0327},
+	0x0123: {0x0067, 0x0327},
+	0x0124: {0x0048, 0x0302},
+	0x0125: {0x0068, 0x0302},
+	0x0128: {0x0049, 0x0303},
+	0x0129: {0x0069, 0x0303},
+	0x012A: {0x0049, 0x0304},
+	0x012B: {0x0069, 0x0304},
+	0x012C: {0x0049, 0x0306},
+	0x012D: {0x0069, 0x0306},
+	0x012E: {0x0049, 0x0328},
+	0x012F: {0x0069, 0x0328},
+	0x0130: {0x0049, 0x0307},
+	0x0134: {0x004A, 0x0302},
+	0x0135: {0x006A, 0x0302},
+	0x0136: {0x004B, 0x0327},
+	0x0137: {0x006B, 0x0327},
+	0x0139: {0x004C, 0x0301},
+	0x013A: {0x006C, 0x0301},
+	0x013B: {0x004C, 0x0327},
+	0x013C: {0x006C, 0x0327},
+	0x013D: {0x004C, 0x030C},
+	0x013E: {0x006C, 0x030C},
+	0x0143: {0x004E, 0x0301},
+	0x0144: {0x006E, 0x0301},
+	0x0145: {0x004E, 0x0327},
+	0x0146: {0x006E, 0x0327},
+	0x0147: {0x004E, 0x030C},
+	0x0148: {0x006E, 0x030C},
+	0x014C: {0x004F, 0x0304},
+	0x014D: {0x006F, 0x0304},
+	0x014E: {0x004F, 0x0306},
+	0x014F: {0x006F, 0x0306},
+	0x0150: {0x004F, 0x030B},
+	0x0151: {0x006F, 0x030B},
+	0x0154: {0x0052, 0x0301},
+	0x0155: {0x0072, 0x0301},
+	0x0156: {0x0052, 0x0327},
+	0x0157: {0x0072, 0x0327},
+	0x0158: {0x0052, 0x030C},
+	0x0159: {0x0072, 0x030C},
+	0x015A: {0x0053, 0x0301},
+	0x015B: {0x0073, 0x0301},
+	0x015C: {0x0053, 0x0302},
+	0x015D: {0x0073, 0x0302},
+	0x015E: {0x0053, 0x0327},
+	0x015F: {0x0073, 0x0327},
+	0x0160: {0x0053, 0x030C},
+	0x0161: {0x0073, 0x030C},
+	0x0162: {0x0054, 0x0327},
+	0x0163: {0x0074, 0x0327},
+	0x0164: {0x0054, 0x030C},
+	0x0165: {0x0074, 0x030C},
+	0x0168: {0x0055, 0x0303},
+	0x0169: {0x0075, 0x0303},
+	0x016A: {0x0055, 0x0304},
+	0x016B: {0x0075, 0x0304},
+	0x016C: {0x0055, 0x0306},
+	0x016D: {0x0075, 0x0306},
+	0x016E: {0x0055, 0x030A},
+	0x016F: {0x0075, 0x030A},
+	0x0170: {0x0055, 0x030B},
+	0x0171: {0x0075, 0x030B},
+	0x0172: {0x0055, 0x0328},
+	0x0173: {0x0075, 0x0328},
+	0x0174: {0x0057, 0x0302},
+	0x0175: {0x0077, 0x0302},
+	0x0176: {0x0059, 0x0302},
+	0x0177: {0x0079, 0x0302},
+	0x0178: {0x0059, 0x0308},
+	0x0179: {0x005A, 0x0301},
+	0x017A: {0x007A, 0x0301},
+	0x017B: {0x005A, 0x0307},
+	0x017C: {0x007A, 0x0307},
+	0x017D: {0x005A, 0x030C},
+	0x017E: {0x007A, 0x030C},
+	0x01A0: {0x004F, 0x031B},
+	0x01A1: {0x006F, 0x031B},
+	0x01AF: {0x0055, 0x031B},
+	0x01B0: {0x0075, 0x031B},
+	0x01CD: {0x0041, 0x030C},
+	0x01CE: {0x0061, 0x030C},
+	0x01CF: {0x0049, 0x030C},
+	0x01D0: {0x0069, 0x030C},
+	0x01D1: {0x004F, 0x030C},
+	0x01D2: {0x006F, 0x030C},
+	0x01D3: {0x0055, 0x030C},
+	0x01D4: {0x0075, 0x030C},
+	0x01D5: {0x00DC, 0x0304},
+	0x01D6: {0x00FC, 0x0304},
+	0x01D7: {0x00DC, 0x0301},
+	0x01D8: {0x00FC, 0x0301},
+	0x01D9: {0x00DC, 0x030C},
+	0x01DA: {0x00FC, 0x030C},
+	0x01DB: {0x00DC, 0x0300},
+	0x01DC: {0x00FC, 0x0300},
+	0x01DE: {0x00C4, 0x0304},
+	0x01DF: {0x00E4, 0x0304},
+	0x01E0: {0x0226, 0x0304},
+	0x01E1: {0x0227, 0x0304},
+	0x01E2: {0x00C6, 0x0304},
+	0x01E3: {0x00E6, 0x0304},
+	0x01E6: {0x0047, 0x030C},
+	0x01E7: {0x0067, 0x030C},
+	0x01E8: {0x004B, 0x030C},
+	0x01E9: {0x006B, 0x030C},
+	0x01EA: {0x004F, 0x0328},
+	0x01EB: {0x006F, 0x0328},
+	0x01EC: {0x01EA, 0x0304},
+	0x01ED: {0x01EB, 0x0304},
+	0x01EE: {0x01B7, 0x030C},
+	0x01EF: {0x0292, 0x030C},
+	0x01F0: {0x006A, 0x030C},
+	0x01F4: {0x0047, 0x0301},
+	0x01F5: {0x0067, 0x0301},
+	0x01F8: {0x004E, 0x0300},
+	0x01F9: {0x006E, 0x0300},
+	0x01FA: {0x00C5, 0x0301},
+	0x01FB: {0x00E5, 0x0301},
+	0x01FC: {0x00C6, 0x0301},
+	0x01FD: {0x00E6, 0x0301},
+	0x01FE: {0x00D8, 0x0301},
+	0x01FF: {0x00F8, 0x0301},
+	0x0200: {0x0041, 0x030F},
+	0x0201: {0x0061, 0x030F},
+	0x0202: {0x0041, 0x0311},
+	0x0203: {0x0061, 0x0311},
+	0x0204: {0x0045, 0x030F},
+	0x0205: {0x0065, 0x030F},
+	0x0206: {0x0045, 0x0311},
+	0x0207: {0x0065, 0x0311},
+	0x0208: {0x0049, 0x030F},
+	0x0209: {0x0069, 0x030F},
+	0x020A: {0x0049, 0x0311},
+	0x020B: {0x0069, 0x0311},
+	0x020C: {0x004F, 0x030F},
+	0x020D: {0x006F, 0x030F},
+	0x020E: {0x004F, 0x0311},
+	0x020F: {0x006F, 0x0311},
+	0x0210: {0x0052, 0x030F},
+	0x0211: {0x0072, 0x030F},
+	0x0212: {0x0052, 0x0311},
+	0x0213: {0x0072, 0x0311},
+	0x0214: {0x0055, 0x030F},
+	0x0215: {0x0075, 0x030F},
+	0x0216: {0x0055, 0x0311},
+	0x0217: {0x0075, 0x0311},
+	0x0218: {0x0053, 0x0326},
+	0x0219: {0x0073, 0x0326},
+	0x021A: {0x0054, 0x0326},
+	0x021B: {0x0074, 0x0326},
+	0x021E: {0x0048, 0x030C},
+	0x021F: {0x0068, 0x030C},
+	0x0226: {0x0041, 0x0307},
+	0x0227: {0x0061, 0x0307},
+	0x0228: {0x0045, 0x0327},
+	0x0229: {0x0065, 0x0327},
+	0x022A: {0x00D6, 0x0304},
+	0x022B: {0x00F6, 0x0304},
+	0x022C: {0x00D5, 0x0304},
+	0x022D: {0x00F5, 0x0304},
+	0x022E: {0x004F, 0x0307},
+	0x022F: {0x006F, 0x0307},
+	0x0230: {0x022E, 0x0304},
+	0x0231: {0x022F, 0x0304},
+	0x0232: {0x0059, 0x0304},
+	0x0233: {0x0079, 0x0304},
+	0x0340: {0x0300},
+	0x0341: {0x0301},
+	0x0343: {0x0313},
+	0x0344: {0x0308, 0x0301},
+	0x0374: {0x02B9},
+	0x037E: {0x003B},
+	0x0385: {0x00A8, 0x0301},
+	0x0386: {0x0391, 0x0301},
+	0x0387: {0x00B7},
+	0x0388: {0x0395, 0x0301},
+	0x0389: {0x0397, 0x0301},
+	0x038A: {0x0399, 0x0301},
+	0x038C: {0x039F, 0x0301},
+	0x038E: {0x03A5, 0x0301},
+	0x038F: {0x03A9, 0x0301},
+	0x0390: {0x03CA, 0x0301},
+	0x03AA: {0x0399, 0x0308},
+	0x03AB: {0x03A5, 0x0308},
+	0x03AC: {0x03B1, 0x0301},
+	0x03AD: {0x03B5, 0x0301},
+	0x03AE: {0x03B7, 0x0301},
+	0x03AF: {0x03B9, 0x0301},
+	0x03B0: {0x03CB, 0x0301},
+	0x03CA: {0x03B9, 0x0308},
+	0x03CB: {0x03C5, 0x0308},
+	0x03CC: {0x03BF, 0x0301},
+	0x03CD: {0x03C5, 0x0301},
+	0x03CE: {0x03C9, 0x0301},
+	0x03D3: {0x03D2, 0x0301},
+	0x03D4: {0x03D2, 0x0308},
+	0x0400: {0x0415, 0x0300},
+	0x0401: {0x0415, 0x0308},
+	0x0403: {0x0413, 0x0301},
+	0x0407: {0x0406, 0x0308},
+	0x040C: {0x041A, 0x0301},
+	0x040D: {0x0418, 0x0300},
+	0x040E: {0x0423, 0x0306},
+	0x0419: {0x0418, 0x0306},
+	0x0439: {0x0438, 0x0306},
+	0x0450: {0x0435, 0x0300},
+	0x0451: {0x0435, 0x0308},
+	0x0453: {0x0433, 0x0301},
+	0x0457: {0x0456, 0x0308},
+	0x045C: {0x043A, 0x0301},
+	0x045D: {0x0438, 0x0300},
+	0x045E: {0x0443, 0x0306},
+	0x0476: {0x0474, 0x030F},
+	0x0477: {0x0475, 0x030F},
+	0x04C1: {0x0416, 0x0306},
+	0x04C2: {0x0436, 0x0306},
+	0x04D0: {0x0410, 0x0306},
+	0x04D1: {0x0430, 0x0306},
+	0x04D2: {0x0410, 0x0308},
+	0x04D3: {0x0430, 0x0308},
+	0x04D6: {0x0415, 0x0306},
+	0x04D7: {0x0435, 0x0306},
+	0x04DA: {0x04D8, 0x0308},
+	0x04DB: {0x04D9, 0x0308},
+	0x04DC: {0x0416, 0x0308},
+	0x04DD: {0x0436, 0x0308},
+	0x04DE: {0x0417, 0x0308},
+	0x04DF: {0x0437, 0x0308},
+	0x04E2: {0x0418, 0x0304},
+	0x04E3: {0x0438, 0x0304},
+	0x04E4: {0x0418, 0x0308},
+	0x04E5: {0x0438, 0x0308},
+	0x04E6: {0x041E, 0x0308},
+	0x04E7: {0x043E, 0x0308},
+	0x04EA: {0x04E8, 0x0308},
+	0x04EB: {0x04E9, 0x0308},
+	0x04EC: {0x042D, 0x0308},
+	0x04ED: {0x044D, 0x0308},
+	0x04EE: {0x0423, 0x0304},
+	0x04EF: {0x0443, 0x0304},
+	0x04F0: {0x0423, 0x0308},
+	0x04F1: {0x0443, 0x0308},
+	0x04F2: {0x0423, 0x030B},
+	0x04F3: {0x0443, 0x030B},
+	0x04F4: {0x0427, 0x0308},
+	0x04F5: {0x0447, 0x0308},
+	0x04F8: {0x042B, 0x0308},
+	0x04F9: {0x044B, 0x0308},
+	0x1E00: {0x0041, 0x0325},
+	0x1E01: {0x0061, 0x0325},
+	0x1E02: {0x0042, 0x0307},
+	0x1E03: {0x0062, 0x0307},
+	0x1E04: {0x0042, 0x0323},
+	0x1E05: {0x0062, 0x0323},
+	0x1E06: {0x0042, 0x0331},
+	0x1E07: {0x0062, 0x0331},
+	0x1E08: {0x00C7, 0x0301},
+	0x1E09: {0x00E7, 0x0301},
+	0x1E0A: {0x0044, 0x0307},
+	0x1E0B: {0x0064, 0x0307},
+	0x1E0C: {0x0044, 0x0323},
+	0x1E0D: {0x0064, 0x0323},
+	0x1E0E: {0x0044, 0x0331},
+	0x1E0F: {0x0064, 0x0331},
+	0x1E10: {0x0044, 0x0327},
+	0x1E11: {0x0064, 0x0327},
+	0x1E12: {0x0044, 0x032D},
+	0x1E13: {0x0064, 0x032D},
+	0x1E14: {0x0112, 0x0300},
+	0x1E15: {0x0113, 0x0300},
+	0x1E16: {0x0112, 0x0301},
+	0x1E17: {0x0113, 0x0301},
+	0x1E18: {0x0045, 0x032D},
+	0x1E19: {0x0065, 0x032D},
+	0x1E1A: {0x0045, 0x0330},
+	0x1E1B: {0x0065, 0x0330},
+	0x1E1C: {0x0228, 0x0306},
+	0x1E1D: {0x0229, 0x0306},
+	0x1E1E: {0x0046, 0x0307},
+	0x1E1F: {0x0066, 0x0307},
+	0x1E20: {0x0047, 0x0304},
+	0x1E21: {0x0067, 0x0304},
+	0x1E22: {0x0048, 0x0307},
+	0x1E23: {0x0068, 0x0307},
+	0x1E24: {0x0048, 0x0323},
+	0x1E25: {0x0068, 0x0323},
+	0x1E26: {0x0048, 0x0308},
+	0x1E27: {0x0068, 0x0308},
+	0x1E28: {0x0048, 0x0327},
+	0x1E29: {0x0068, 0x0327},
+	0x1E2A: {0x0048, 0x032E},
+	0x1E2B: {0x0068, 0x032E},
+	0x1E2C: {0x0049, 0x0330},
+	0x1E2D: {0x0069, 0x0330},
+	0x1E2E: {0x00CF, 0x0301},
+	0x1E2F: {0x00EF, 0x0301},
+	0x1E30: {0x004B, 0x0301},
+	0x1E31: {0x006B, 0x0301},
+	0x1E32: {0x004B, 0x0323},
+	0x1E33: {0x006B, 0x0323},
+	0x1E34: {0x004B, 0x0331},
+	0x1E35: {0x006B, 0x0331},
+	0x1E36: {0x004C, 0x0323},
+	0x1E37: {0x006C, 0x0323},
+	0x1E38: {0x1E36, 0x0304},
+	0x1E39: {0x1E37, 0x0304},
+	0x1E3A: {0x004C, 0x0331},
+	0x1E3B: {0x006C, 0x0331},
+	0x1E3C: {0x004C, 0x032D},
+	0x1E3D: {0x006C, 0x032D},
+	0x1E3E: {0x004D, 0x0301},
+	0x1E3F: {0x006D, 0x0301},
+	0x1E40: {0x004D, 0x0307},
+	0x1E41: {0x006D, 0x0307},
+	0x1E42: {0x004D, 0x0323},
+	0x1E43: {0x006D, 0x0323},
+	0x1E44: {0x004E, 0x0307},
+	0x1E45: {0x006E, 0x0307},
+	0x1E46: {0x004E, 0x0323},
+	0x1E47: {0x006E, 0x0323},
+	0x1E48: {0x004E, 0x0331},
+	0x1E49: {0x006E, 0x0331},
+	0x1E4A: {0x004E, 0x032D},
+	0x1E4B: {0x006E, 0x032D},
+	0x1E4C: {0x00D5, 0x0301},
+	0x1E4D: {0x00F5, 0x0301},
+	0x1E4E: {0x00D5, 0x0308},
+	0x1E4F: {0x00F5, 0x0308},
+	0x1E50: {0x014C, 0x0300},
+	0x1E51: {0x014D, 0x0300},
+	0x1E52: {0x014C, 0x0301},
+	0x1E53: {0x014D, 0x0301},
+	0x1E54: {0x0050, 0x0301},
+	0x1E55: {0x0070, 0x0301},
+	0x1E56: {0x0050, 0x0307},
+	0x1E57: {0x0070, 0x0307},
+	0x1E58: {0x0052, 0x0307},
+	0x1E59: {0x0072, 0x0307},
+	0x1E5A: {0x0052, 0x0323},
+	0x1E5B: {0x0072, 0x0323},
+	0x1E5C: {0x1E5A, 0x0304},
+	0x1E5D: {0x1E5B, 0x0304},
+	0x1E5E: {0x0052, 0x0331},
+	0x1E5F: {0x0072, 0x0331},
+	0x1E60: {0x0053, 0x0307},
+	0x1E61: {0x0073, 0x0307},
+	0x1E62: {0x0053, 0x0323},
+	0x1E63: {0x0073, 0x0323},
+	0x1E64: {0x015A, 0x0307},
+	0x1E65: {0x015B, 0x0307},
+	0x1E66: {0x0160, 0x0307},
+	0x1E67: {0x0161, 0x0307},
+	0x1E68: {0x1E62, 0x0307},
+	0x1E69: {0x1E63, 0x0307},
+	0x1E6A: {0x0054, 0x0307},
+	0x1E6B: {0x0074, 0x0307},
+	0x1E6C: {0x0054, 0x0323},
+	0x1E6D: {0x0074, 0x0323},
+	0x1E6E: {0x0054, 0x0331},
+	0x1E6F: {0x0074, 0x0331},
+	0x1E70: {0x0054, 0x032D},
+	0x1E71: {0x0074, 0x032D},
+	0x1E72: {0x0055, 0x0324},
+	0x1E73: {0x0075, 0x0324},
+	0x1E74: {0x0055, 0x0330},
+	0x1E75: {0x0075, 0x0330},
+	0x1E76: {0x0055, 0x032D},
+	0x1E77: {0x0075, 0x032D},
+	0x1E78: {0x0168, 0x0301},
+	0x1E79: {0x0169, 0x0301},
+	0x1E7A: {0x016A, 0x0308},
+	0x1E7B: {0x016B, 0x0308},
+	0x1E7C: {0x0056, 0x0303},
+	0x1E7D: {0x0076, 0x0303},
+	0x1E7E: {0x0056, 0x0323},
+	0x1E7F: {0x0076, 0x0323},
+	0x1E80: {0x0057, 0x0300},
+	0x1E81: {0x0077, 0x0300},
+	0x1E82: {0x0057, 0x0301},
+	0x1E83: {0x0077, 0x0301},
+	0x1E84: {0x0057, 0x0308},
+	0x1E85: {0x0077, 0x0308},
+	0x1E86: {0x0057, 0x0307},
+	0x1E87: {0x0077, 0x0307},
+	0x1E88: {0x0057, 0x0323},
+	0x1E89: {0x0077, 0x0323},
+	0x1E8A: {0x0058, 0x0307},
+	0x1E8B: {0x0078, 0x0307},
+	0x1E8C: {0x0058, 0x0308},
+	0x1E8D: {0x0078, 0x0308},
+	0x1E8E: {0x0059, 0x0307},
+	0x1E8F: {0x0079, 0x0307},
+	0x1E90: {0x005A, 0x0302},
+	0x1E91: {0x007A, 0x0302},
+	0x1E92: {0x005A, 0x0323},
+	0x1E93: {0x007A, 0x0323},
+	0x1E94: {0x005A, 0x0331},
+	0x1E95: {0x007A, 0x0331},
+	0x1E96: {0x0068, 0x0331},
+	0x1E97: {0x0074, 0x0308},
+	0x1E98: {0x0077, 0x030A},
+	0x1E99: {0x0079, 0x030A},
+	0x1E9B: {0x017F, 0x0307},
+	0x1EA0: {0x0041, 0x0323},
+	0x1EA1: {0x0061, 0x0323},
+	0x1EA2: {0x0041, 0x0309},
+	0x1EA3: {0x0061, 0x0309},
+	0x1EA4: {0x00C2, 0x0301},
+	0x1EA5: {0x00E2, 0x0301},
+	0x1EA6: {0x00C2, 0x0300},
+	0x1EA7: {0x00E2, 0x0300},
+	0x1EA8: {0x00C2, 0x0309},
+	0x1EA9: {0x00E2, 0x0309},
+	0x1EAA: {0x00C2, 0x0303},
+	0x1EAB: {0x00E2, 0x0303},
+	0x1EAC: {0x1EA0, 0x0302},
+	0x1EAD: {0x1EA1, 0x0302},
+	0x1EAE: {0x0102, 0x0301},
+	0x1EAF: {0x0103, 0x0301},
+	0x1EB0: {0x0102, 0x0300},
+	0x1EB1: {0x0103, 0x0300},
+	0x1EB2: {0x0102, 0x0309},
+	0x1EB3: {0x0103, 0x0309},
+	0x1EB4: {0x0102, 0x0303},
+	0x1EB5: {0x0103, 0x0303},
+	0x1EB6: {0x1EA0, 0x0306},
+	0x1EB7: {0x1EA1, 0x0306},
+	0x1EB8: {0x0045, 0x0323},
+	0x1EB9: {0x0065, 0x0323},
+	0x1EBA: {0x0045, 0x0309},
+	0x1EBB: {0x0065, 0x0309},
+	0x1EBC: {0x0045, 0x0303},
+	0x1EBD: {0x0065, 0x0303},
+	0x1EBE: {0x00CA, 0x0301},
+	0x1EBF: {0x00EA, 0x0301},
+	0x1EC0: {0x00CA, 0x0300},
+	0x1EC1: {0x00EA, 0x0300},
+	0x1EC2: {0x00CA, 0x0309},
+	0x1EC3: {0x00EA, 0x0309},
+	0x1EC4: {0x00CA, 0x0303},
+	0x1EC5: {0x00EA, 0x0303},
+	0x1EC6: {0x1EB8, 0x0302},
+	0x1EC7: {0x1EB9, 0x0302},
+	0x1EC8: {0x0049, 0x0309},
+	0x1EC9: {0x0069, 0x0309},
+	0x1ECA: {0x0049, 0x0323},
+	0x1ECB: {0x0069, 0x0323},
+	0x1ECC: {0x004F, 0x0323},
+	0x1ECD: {0x006F, 0x0323},
+	0x1ECE: {0x004F, 0x0309},
+	0x1ECF: {0x006F, 0x0309},
+	0x1ED0: {0x00D4, 0x0301},
+	0x1ED1: {0x00F4, 0x0301},
+	0x1ED2: {0x00D4, 0x0300},
+	0x1ED3: {0x00F4, 0x0300},
+	0x1ED4: {0x00D4, 0x0309},
+	0x1ED5: {0x00F4, 0x0309},
+	0x1ED6: {0x00D4, 0x0303},
+	0x1ED7: {0x00F4, 0x0303},
+	0x1ED8: {0x1ECC, 0x0302},
+	0x1ED9: {0x1ECD, 0x0302},
+	0x1EDA: {0x01A0, 0x0301},
+	0x1EDB: {0x01A1, 0x0301},
+	0x1EDC: {0x01A0, 0x0300},
+	0x1EDD: {0x01A1, 0x0300},
+	0x1EDE: {0x01A0, 0x0309},
+	0x1EDF: {0x01A1, 0x0309},
+	0x1EE0: {0x01A0, 0x0303},
+	0x1EE1: {0x01A1, 0x0303},
+	0x1EE2: {0x01A0, 0x0323},
+	0x1EE3: {0x01A1, 0x0323},
+	0x1EE4: {0x0055, 0x0323},
+	0x1EE5: {0x0075, 0x0323},
+	0x1EE6: {0x0055, 0x0309},
+	0x1EE7: {0x0075, 0x0309},
+	0x1EE8: {0x01AF, 0x0301},
+	0x1EE9: {0x01B0, 0x0301},
+	0x1EEA: {0x01AF, 0x0300},
+	0x1EEB: {0x01B0, 0x0300},
+	0x1EEC: {0x01AF, 0x0309},
+	0x1EED: {0x01B0, 0x0309},
+	0x1EEE: {0x01AF, 0x0303},
+	0x1EEF: {0x01B0, 0x0303},
+	0x1EF0: {0x01AF, 0x0323},
+	0x1EF1: {0x01B0, 0x0323},
+	0x1EF2: {0x0059, 0x0300},
+	0x1EF3: {0x0079, 0x0300},
+	0x1EF4: {0x0059, 0x0323},
+	0x1EF5: {0x0079, 0x0323},
+	0x1EF6: {0x0059, 0x0309},
+	0x1EF7: {0x0079, 0x0309},
+	0x1EF8: {0x0059, 0x0303},
+	0x1EF9: {0x0079, 0x0303},
+	0x1F00: {0x03B1, 0x0313},
+	0x1F01: {0x03B1, 0x0314},
+	0x1F02: {0x1F00, 0x0300},
+	0x1F03: {0x1F01, 0x0300},
+	0x1F04: {0x1F00, 0x0301},
+	0x1F05: {0x1F01, 0x0301},
+	0x1F06: {0x1F00, 0x0342},
+	0x1F07: {0x1F01, 0x0342},
+	0x1F08: {0x0391, 0x0313},
+	0x1F09: {0x0391, 0x0314},
+	0x1F0A: {0x1F08, 0x0300},
+	0x1F0B: {0x1F09, 0x0300},
+	0x1F0C: {0x1F08, 0x0301},
+	0x1F0D: {0x1F09, 0x0301},
+	0x1F0E: {0x1F08, 0x0342},
+	0x1F0F: {0x1F09, 0x0342},
+	0x1F10: {0x03B5, 0x0313},
+	0x1F11: {0x03B5, 0x0314},
+	0x1F12: {0x1F10, 0x0300},
+	0x1F13: {0x1F11, 0x0300},
+	0x1F14: {0x1F10, 0x0301},
+	0x1F15: {0x1F11, 0x0301},
+	0x1F18: {0x0395, 0x0313},
+	0x1F19: {0x0395, 0x0314},
+	0x1F1A: {0x1F18, 0x0300},
+	0x1F1B: {0x1F19, 0x0300},
+	0x1F1C: {0x1F18, 0x0301},
+	0x1F1D: {0x1F19, 0x0301},
+	0x1F20: {0x03B7, 0x0313},
+	0x1F21: {0x03B7, 0x0314},
+	0x1F22: {0x1F20, 0x0300},
+	0x1F23: {0x1F21, 0x0300},
+	0x1F24: {0x1F20, 0x0301},
+	0x1F25: {0x1F21, 0x0301},
+	0x1F26: {0x1F20, 0x0342},
+	0x1F27: {0x1F21, 0x0342},
+	0x1F28: {0x0397, 0x0313},
+	0x1F29: {0x0397, 0x0314},
+	0x1F2A: {0x1F28, 0x0300},
+	0x1F2B: {0x1F29, 0x0300},
+	0x1F2C: {0x1F28, 0x0301},
+	0x1F2D: {0x1F29, 0x0301},
+	0x1F2E: {0x1F28, 0x0342},
+	0x1F2F: {0x1F29, 0x0342},
+	0x1F30: {0x03B9, 0x0313},
+	0x1F31: {0x03B9, 0x0314},
+	0x1F32: {0x1F30, 0x0300},
+	0x1F33: {0x1F31, 0x0300},
+	0x1F34: {0x1F30, 0x0301},
+	0x1F35: {0x1F31, 0x0301},
+	0x1F36: {0x1F30, 0x0342},
+	0x1F37: {0x1F31, 0x0342},
+	0x1F38: {0x0399, 0x0313},
+	0x1F39: {0x0399, 0x0314},
+	0x1F3A: {0x1F38, 0x0300},
+	0x1F3B: {0x1F39, 0x0300},
+	0x1F3C: {0x1F38, 0x0301},
+	0x1F3D: {0x1F39, 0x0301},
+	0x1F3E: {0x1F38, 0x0342},
+	0x1F3F: {0x1F39, 0x0342},
+	0x1F40: {0x03BF, 0x0313},
+	0x1F41: {0x03BF, 0x0314},
+	0x1F42: {0x1F40, 0x0300},
+	0x1F43: {0x1F41, 0x0300},
+	0x1F44: {0x1F40, 0x0301},
+	0x1F45: {0x1F41, 0x0301},
+	0x1F48: {0x039F, 0x0313},
+	0x1F49: {0x039F, 0x0314},
+	0x1F4A: {0x1F48, 0x0300},
+	0x1F4B: {0x1F49, 0x0300},
+	0x1F4C: {0x1F48, 0x0301},
+	0x1F4D: {0x1F49, 0x0301},
+	0x1F50: {0x03C5, 0x0313},
+	0x1F51: {0x03C5, 0x0314},
+	0x1F52: {0x1F50, 0x0300},
+	0x1F53: {0x1F51, 0x0300},
+	0x1F54: {0x1F50, 0x0301},
+	0x1F55: {0x1F51, 0x0301},
+	0x1F56: {0x1F50, 0x0342},
+	0x1F57: {0x1F51, 0x0342},
+	0x1F59: {0x03A5, 0x0314},
+	0x1F5B: {0x1F59, 0x0300},
+	0x1F5D: {0x1F59, 0x0301},
+	0x1F5F: {0x1F59, 0x0342},
+	0x1F60: {0x03C9, 0x0313},
+	0x1F61: {0x03C9, 0x0314},
+	0x1F62: {0x1F60, 0x0300},
+	0x1F63: {0x1F61, 0x0300},
+	0x1F64: {0x1F60, 0x0301},
+	0x1F65: {0x1F61, 0x0301},
+	0x1F66: {0x1F60, 0x0342},
+	0x1F67: {0x1F61, 0x0342},
+	0x1F68: {0x03A9, 0x0313},
+	0x1F69: {0x03A9, 0x0314},
+	0x1F6A: {0x1F68, 0x0300},
+	0x1F6B: {0x1F69, 0x0300},
+	0x1F6C: {0x1F68, 0x0301},
+	0x1F6D: {0x1F69, 0x0301},
+	0x1F6E: {0x1F68, 0x0342},
+	0x1F6F: {0x1F69, 0x0342},
+	0x1F70: {0x03B1, 0x0300},
+	0x1F71: {0x03AC},
+	0x1F72: {0x03B5, 0x0300},
+	0x1F73: {0x03AD},
+	0x1F74: {0x03B7, 0x0300},
+	0x1F75: {0x03AE},
+	0x1F76: {0x03B9, 0x0300},
+	0x1F77: {0x03AF},
+	0x1F78: {0x03BF, 0x0300},
+	0x1F79: {0x03CC},
+	0x1F7A: {0x03C5, 0x0300},
+	0x1F7B: {0x03CD},
+	0x1F7C: {0x03C9, 0x0300},
+	0x1F7D: {0x03CE},
+	0x1F80: {0x1F00, 0x0345},
+	0x1F81: {0x1F01, 0x0345},
+	0x1F82: {0x1F02, 0x0345},
+	0x1F83: {0x1F03, 0x0345},
+	0x1F84: {0x1F04, 0x0345},
+	0x1F85: {0x1F05, 0x0345},
+	0x1F86: {0x1F06, 0x0345},
+	0x1F87: {0x1F07, 0x0345},
+	0x1F88: {0x1F08, 0x0345},
+	0x1F89: {0x1F09, 0x0345},
+	0x1F8A: {0x1F0A, 0x0345},
+	0x1F8B: {0x1F0B, 0x0345},
+	0x1F8C: {0x1F0C, 0x0345},
+	0x1F8D: {0x1F0D, 0x0345},
+	0x1F8E: {0x1F0E, 0x0345},
+	0x1F8F: {0x1F0F, 0x0345},
+	0x1F90: {0x1F20, 0x0345},
+	0x1F91: {0x1F21, 0x0345},
+	0x1F92: {0x1F22, 0x0345},
+	0x1F93: {0x1F23, 0x0345},
+	0x1F94: {0x1F24, 0x0345},
+	0x1F95: {0x1F25, 0x0345},
+	0x1F96: {0x1F26, 0x0345},
+	0x1F97: {0x1F27, 0x0345},
+	0x1F98: {0x1F28, 0x0345},
+	0x1F99: {0x1F29, 0x0345},
+	0x1F9A: {0x1F2A, 0x0345},
+	0x1F9B: {0x1F2B, 0x0345},
+	0x1F9C: {0x1F2C, 0x0345},
+	0x1F9D: {0x1F2D, 0x0345},
+	0x1F9E: {0x1F2E, 0x0345},
+	0x1F9F: {0x1F2F, 0x0345},
+	0x1FA0: {0x1F60, 0x0345},
+	0x1FA1: {0x1F61, 0x0345},
+	0x1FA2: {0x1F62, 0x0345},
+	0x1FA3: {0x1F63, 0x0345},
+	0x1FA4: {0x1F64, 0x0345},
+	0x1FA5: {0x1F65, 0x0345},
+	0x1FA6: {0x1F66, 0x0345},
+	0x1FA7: {0x1F67, 0x0345},
+	0x1FA8: {0x1F68, 0x0345},
+	0x1FA9: {0x1F69, 0x0345},
+	0x1FAA: {0x1F6A, 0x0345},
+	0x1FAB: {0x1F6B, 0x0345},
+	0x1FAC: {0x1F6C, 0x0345},
+	0x1FAD: {0x1F6D, 0x0345},
+	0x1FAE: {0x1F6E, 0x0345},
+	0x1FAF: {0x1F6F, 0x0345},
+	0x1FB0: {0x03B1, 0x0306},
+	0x1FB1: {0x03B1, 0x0304},
+	0x1FB2: {0x1F70, 0x0345},
+	0x1FB3: {0x03B1, 0x0345},
+	0x1FB4: {0x03AC, 0x0345},
+	0x1FB6: {0x03B1, 0x0342},
+	0x1FB7: {0x1FB6, 0x0345},
+	0x1FB8: {0x0391, 0x0306},
+	0x1FB9: {0x0391, 0x0304},
+	0x1FBA: {0x0391, 0x0300},
+	0x1FBB: {0x0386},
+	0x1FBC: {0x0391, 0x0345},
+	0x1FBE: {0x03B9},
+	0x1FC1: {0x00A8, 0x0342},
+	0x1FC2: {0x1F74, 0x0345},
+	0x1FC3: {0x03B7, 0x0345},
+	0x1FC4: {0x03AE, 0x0345},
+	0x1FC6: {0x03B7, 0x0342},
+	0x1FC7: {0x1FC6, 0x0345},
+	0x1FC8: {0x0395, 0x0300},
+	0x1FC9: {0x0388},
+	0x1FCA: {0x0397, 0x0300},
+	0x1FCB: {0x0389},
+	0x1FCC: {0x0397, 0x0345},
+	0x1FCD: {0x1FBF, 0x0300},
+	0x1FCE: {0x1FBF, 0x0301},
+	0x1FCF: {0x1FBF, 0x0342},
+	0x1FD0: {0x03B9, 0x0306},
+	0x1FD1: {0x03B9, 0x0304},
+	0x1FD2: {0x03CA, 0x0300},
+	0x1FD3: {0x0390},
+	0x1FD6: {0x03B9, 0x0342},
+	0x1FD7: {0x03CA, 0x0342},
+	0x1FD8: {0x0399, 0x0306},
+	0x1FD9: {0x0399, 0x0304},
+	0x1FDA: {0x0399, 0x0300},
+	0x1FDB: {0x038A},
+	0x1FDD: {0x1FFE, 0x0300},
+	0x1FDE: {0x1FFE, 0x0301},
+	0x1FDF: {0x1FFE, 0x0342},
+	0x1FE0: {0x03C5, 0x0306},
+	0x1FE1: {0x03C5, 0x0304},
+	0x1FE2: {0x03CB, 0x0300},
+	0x1FE3: {0x03B0},
+	0x1FE4: {0x03C1, 0x0313},
+	0x1FE5: {0x03C1, 0x0314},
+	0x1FE6: {0x03C5, 0x0342},
+	0x1FE7: {0x03CB, 0x0342},
+	0x1FE8: {0x03A5, 0x0306},
+	0x1FE9: {0x03A5, 0x0304},
+	0x1FEA: {0x03A5, 0x0300},
+	0x1FEB: {0x038E},
+	0x1FEC: {0x03A1, 0x0314},
+	0x1FED: {0x00A8, 0x0300},
+	0x1FEE: {0x0385},
+	0x1FEF: {0x0060},
+	0x1FF2: {0x1F7C, 0x0345},
+	0x1FF3: {0x03C9, 0x0345},
+	0x1FF4: {0x03CE, 0x0345},
+	0x1FF6: {0x03C9, 0x0342},
+	0x1FF7: {0x1FF6, 0x0345},
+	0x1FF8: {0x039F, 0x0300},
+	0x1FF9: {0x038C},
+	0x1FFA: {0x03A9, 0x0300},
+	0x1FFB: {0x038F},
+	0x1FFC: {0x03A9, 0x0345},
+	0x1FFD: {0x00B4},
+	0x2000: {0x2002},
+	0x2001: {0x2003},
+	0x2126: {0x03A9},
+	0x212A: {0x004B},
+	0x212B: {0x00C5},
+}
+
+// Characters with a canonical decomposition into two characters which are not recomposed by NFC.
+var compositionExclusions = []rune{
+	0x0344,
+}
+
+// Compatibility decompositions, one level deep.
+var compatibilityDecompositions = map[rune][]rune{
+	0x00A0: {0x0020},
+	0x00A8: {0x0020, 0x0308},
+	0x00AA: {0x0061},
+	0x00AF: {0x0020, 0x0304},
+	0x00B2: {0x0032},
+	0x00B3: {0x0033},
+	0x00B4: {0x0020, 0x0301},
+	0x00B5: {0x03BC},
+	0x00B8: {0x0020, 0x0327},
+	0x00B9: {0x0031},
+	0x00BA: {0x006F},
+	0x00BC: {0x0031, 0x2044, 0x0034},
+	0x00BD: {0x0031, 0x2044, 0x0032},
+	0x00BE: {0x0033, 0x2044, 0x0034},
+	0x0132: {0x0049, 0x004A},
+	0x0133: {0x0069, 0x006A},
+	0x013F: {0x004C, 0x00B7},
+	0x0140: {0x006C, 0x00B7},
+	0x0149: {0x02BC, 0x006E},
+	0x017F: {0x0073},
+	0x01C4: {0x0044, 0x017D},
+	0x01C5: {0x0044, 0x017E},
+	0x01C6: {0x0064, 0x017E},
+	0x01C7: {0x004C, 0x004A},
+	0x01C8: {0x004C, 0x006A},
+	0x01C9: {0x006C, 0x006A},
+	0x01CA: {0x004E, 0x004A},
+	0x01CB: {0x004E, 0x006A},
+	0x01CC: {0x006E, 0x006A},
+	0x01F1: {0x0044, 0x005A},
+	0x01F2: {0x0044, 0x007A},
+	0x01F3: {0x0064, 0x007A},
+	0x2002: {0x0020},
+	0x2003: {0x0020},
+	0x2004: {0x0020},
+	0x2005: {0x0020},
+	0x2006: {0x0020},
+	0x2007: {0x0020},
+	0x2008: {0x0020},
+	0x2009: {0x0020},
+	0x200A: {0x0020},
+	0x2011: {0x2010},
+	0x2017: {0x0020, 0x0333},
+	0x2024: {0x002E},
+	0x2025: {0x002E, 0x002E},
+	0x2026: {0x002E, 0x002E, 0x002E},
+	0x202F: {0x0020},
+	0x2033: {0x2032, 0x2032},
+	0x2034: {0x2032, 0x2032, 0x2032},
+	0x2036: {0x2035, 0x2035},
+	0x2037: {0x2035, 0x2035, 0x2035},
+	0x203C: {0x0021, 0x0021},
+	0x203E: {0x0020, 0x0305},
+	0x2047: {0x003F, 0x003F},
+	0x2048: {0x003F, 0x0021},
+	0x2049: {0x0021, 0x003F},
+	0x2057: {0x2032, 0x2032, 0x2032, 0x2032},
+	0x205F: {0x0020},
+	0x2070: {0x0030},
+	0x2071: {0x0069},
+	0x2074: {0x0034},
+	0x2075: {0x0035},
+	0x2076: {0x0036},
+	0x2077: {0x0037},
+	0x2078: {0x0038},
+	0x2079: {0x0039},
+	0x207A: {0x002B},
+	0x207B: {0x2212},
+	0x207C: {0x003D},
+	0x207D: {0x0028},
+	0x207E: {0x0029},
+	0x207F: {0x006E},
+	0x2080: {0x0030},
+	0x2081: {0x0031},
+	0x2082: {0x0032},
+	0x2083: {0x0033},
+	0x2084: {0x0034},
+	0x2085: {0x0035},
+	0x2086: {0x0036},
+	0x2087: {0x0037},
+	0x2088: {0x0038},
+	0x2089: {0x0039},
+	0x208A: {0x002B},
+	0x208B: {0x2212},
+	0x208C: {0x003D},
+	0x208D: {0x0028},
+	0x208E: {0x0029},
+	0x2090: {0x0061},
+	0x2091: {0x0065},
+	0x2092: {0x006F},
+	0x2093: {0x0078},
+	0x2094: {0x0259},
+	0x2095: {0x0068},
+	0x2096: {0x006B},
+	0x2097: {0x006C},
+	0x2098: {0x006D},
+	0x2099: {0x006E},
+	0x209A: {0x0070},
+	0x209B: {0x0073},
+	0x209C: {0x0074},
+	0x2100: {0x0061, 0x002F, 0x0063},
+	0x2101: {0x0061, 0x002F, 0x0073},
+	0x2102: {0x0043},
+	0x2103: {0x00B0, 0x0043},
+	0x2105: {0x0063, 0x002F, 0x006F},
+	0x2106: {0x0063, 0x002F, 0x0075},
+	0x2107: {0x0190},
+	0x2109: {0x00B0, 0x0046},
+	0x210A: {0x0067},
+	0x210B: {0x0048},
+	0x210C: {0x0048},
+	0x210D: {0x0048},
+	0x210E: {0x0068},
+	0x210F: {0x0127},
+	0x2110: {0x0049},
+	0x2111: {0x0049},
+	0x2112: {0x004C},
+	0x2113: {0x006C},
+	0x2115: {0x004E},
+	0x2116: {0x004E, 0x006F},
+	0x2119: {0x0050},
+	0x211A: {0x0051},
+	0x211B: {0x0052},
+	0x211C: {0x0052},
+	0x211D: {0x0052},
+	0x2120: {0x0053, 0x004D},
+	0x2121: {0x0054, 0x0045, 0x004C},
+	0x2122: {0x0054, 0x004D},
+	0x2124: {0x005A},
+	0x2128: {0x005A},
+	0x212C: {0x0042},
+	0x212D: {0x0043},
+	0x212F: {0x0065},
+	0x2130: {0x0045},
+	0x2131: {0x0046},
+	0x2133: {0x004D},
+	0x2134: {0x006F},
+	0x2135: {0x05D0},
+	0x2136: {0x05D1},
+	0x2137: {0x05D2},
+	0x2138: {0x05D3},
+	0x2139: {0x0069},
+	0x213B: {0x0046, 0x0041, 0x0058},
+	0x213C: {0x03C0},
+	0x213D: {0x03B3},
+	0x213E: {0x0393},
+	0x213F: {0x03A0},
+	0x2140: {0x2211},
+	0x2145: {0x0044},
+	0x2146: {0x0064},
+	0x2147: {0x0065},
+	0x2148: {0x0069},
+	0x2149: {0x006A},
+	0x2150: {0x0031, 0x2044, 0x0037},
+	0x2151: {0x0031, 0x2044, 0x0039},
+	0x2152: {0x0031, 0x2044, 0x0031, 0x0030},
+	0x2153: {0x0031, 0x2044, 0x0033},
+	0x2154: {0x0032, 0x2044, 0x0033},
+	0x2155: {0x0031, 0x2044, 0x0035},
+	0x2156: {0x0032, 0x2044, 0x0035},
+	0x2157: {0x0033, 0x2044, 0x0035},
+	0x2158: {0x0034, 0x2044, 0x0035},
+	0x2159: {0x0031, 0x2044, 0x0036},
+	0x215A: {0x0035, 0x2044, 0x0036},
+	0x215B: {0x0031, 0x2044, 0x0038},
+	0x215C: {0x0033, 0x2044, 0x0038},
+	0x215D: {0x0035, 0x2044, 0x0038},
+	0x215E: {0x0037, 0x2044, 0x0038},
+	0x215F: {0x0031, 0x2044},
+	0x2160: {0x0049},
+	0x2161: {0x0049, 0x0049},
+	0x2162: {0x0049, 0x0049, 0x0049},
+	0x2163: {0x0049, 0x0056},
+	0x2164: {0x0056},
+	0x2165: {0x0056, 0x0049},
+	0x2166: {0x0056, 0x0049, 0x0049},
+	0x2167: {0x0056, 0x0049, 0x0049, 0x0049},
+	0x2168: {0x0049, 0x0058},
+	0x2169: {0x0058},
+	0x216A: {0x0058, 0x0049},
+	0x216B: {0x0058, 0x0049, 0x0049},
+	0x216C: {0x004C},
+	0x216D: {0x0043},
+	0x216E: {0x0044},
+	0x216F: {0x004D},
+	0x2170: {0x0069},
+	0x2171: {0x0069, 0x0069},
+	0x2172: {0x0069, 0x0069, 0x0069},
+	0x2173: {0x0069, 0x0076},
+	0x2174: {0x0076},
+	0x2175: {0x0076, 0x0069},
+	0x2176: {0x0076, 0x0069, 0x0069},
+	0x2177: {0x0076, 0x0069, 0x0069, 0x0069},
+	0x2178: {0x0069, 0x0078},
+	0x2179: {0x0078},
+	0x217A: {0x0078, 0x0069},
+	0x217B: {0x0078, 0x0069, 0x0069},
+	0x217C: {0x006C},
+	0x217D: {0x0063},
+	0x217E: {0x0064},
+	0x217F: {0x006D},
+	0x2189: {0x0030, 0x2044, 0x0033},
+	0x2460: {0x0031},
+	0x2461: {0x0032},
+	0x2462: {0x0033},
+	0x2463: {0x0034},
+	0x2464: {0x0035},
+	0x2465: {0x0036},
+	0x2466: {0x0037},
+	0x2467: {0x0038},
+	0x2468: {0x0039},
+	0x2469: {0x0031, 0x0030},
+	0x246A: {0x0031, 0x0031},
+	0x246B: {0x0031, 0x0032},
+	0x246C: {0x0031, 0x0033},
+	0x246D: {0x0031, 0x0034},
+	0x246E: {0x0031, 0x0035},
+	0x246F: {0x0031, 0x0036},
+	0x2470: {0x0031, 0x0037},
+	0x2471: {0x0031, 0x0038},
+	0x2472: {0x0031, 0x0039},
+	0x2473: {0x0032, 0x0030},
+	0x2474: {0x0028, 0x0031, 0x0029},
+	0x2475: {0x0028, 0x0032, 0x0029},
+	0x2476: {0x0028, 0x0033, 0x0029},
+	0x2477: {0x0028, 0x0034, 0x0029},
+	0x2478: {0x0028, 0x0035, 0x0029},
+	0x2479: {0x0028, 0x0036, 0x0029},
+	0x247A: {0x0028, 0x0037, 0x0029},
+	0x247B: {0x0028, 0x0038, 0x0029},
+	0x247C: {0x0028, 0x0039, 0x0029},
+	0x247D: {0x0028, 0x0031, 0x0030, 0x0029},
+	0x247E: {0x0028, 0x0031, 0x0031, 0x0029},
+	0x247F: {0x0028, 0x0031, 0x0032, 0x0029},
+	0x2480: {0x0028, 0x0031, 0x0033, 0x0029},
+	0x2481: {0x0028, 0x0031, 0x0034, 0x0029},
+	0x2482: {0x0028, 0x0031, 0x0035, 0x0029},
+	0x2483: {0x0028, 0x0031, 0x0036, 0x0029},
+	0x2484: {0x0028, 0x0031, 0x0037, 0x0029},
+	0x2485: {0x0028, 0x0031, 0x0038, 0x0029},
+	0x2486: {0x0028, 0x0031, 0x0039, 0x0029},
+	0x2487: {0x0028, 0x0032, 0x0030, 0x0029},
+	0x2488: {0x0031, 0x002E},
+	0x2489: {0x0032, 0x002E},
+	0x248A: {0x0033, 0x002E},
+	0x248B: {0x0034, 0x002E},
+	0x248C: {0x0035, 0x002E},
+	0x248D: {0x0036, 0x002E},
+	0x248E: {0x0037, 0x002E},
+	0x248F: {0x0038, 0x002E},
+	0x2490: {0x0039, 0x002E},
+	0x2491: {0x0031, 0x0030, 0x002E},
+	0x2492: {0x0031, 0x0031, 0x002E},
+	0x2493: {0x0031, 0x0032, 0x002E},
+	0x2494: {0x0031, 0x0033, 0x002E},
+	0x2495: {0x0031, 0x0034, 0x002E},
+	0x2496: {0x0031, 0x0035, 0x002E},
+	0x2497: {0x0031, 0x0036, 0x002E},
+	0x2498: {0x0031, 0x0037, 0x002E},
+	0x2499: {0x0031, 0x0038, 0x002E},
+	0x249A: {0x0031, 0x0039, 0x002E},
+	0x249B: {0x0032, 0x0030, 0x002E},
+	0x249C: {0x0028, 0x0061, 0x0029},
+	0x249D: {0x0028, 0x0062, 0x0029},
+	0x249E: {0x0028, 0x0063, 0x0029},
+	0x249F: {0x0028, 0x0064, 0x0029},
+	0x24A0: {0x0028, 0x0065, 0x0029},
+	0x24A1: {0x0028, 0x0066, 0x0029},
+	0x24A2: {0x0028, 0x0067, 0x0029},
+	0x24A3: {0x0028, 0x0068, 0x0029},
+	0x24A4: {0x0028, 0x0069, 0x0029},
+	0x24A5: {0x0028, 0x006A, 0x0029},
+	0x24A6: {0x0028, 0x006B, 0x0029},
+	0x24A7: {0x0028, 0x006C, 0x0029},
+	0x24A8: {0x0028, 0x006D, 0x0029},
+	0x24A9: {0x0028, 0x006E, 0x0029},
+	0x24AA: {0x0028, 0x006F, 0x0029},
+	0x24AB: {0x0028, 0x0070, 0x0029},
+	0x24AC: {0x0028, 0x0071, 0x0029},
+	0x24AD: {0x0028, 0x0072, 0x0029},
+	0x24AE: {0x0028, 0x0073, 0x0029},
+	0x24AF: {0x0028, 0x0074, 0x0029},
+	0x24B0: {0x0028, 0x0075, 0x0029},
+	0x24B1: {0x0028, 0x0076, 0x0029},
+	0x24B2: {0x0028, 0x0077, 0x0029},
+	0x24B3: {0x0028, 0x0078, 0x0029},
+	0x24B4: {0x0028, 0x0079, 0x0029},
+	0x24B5: {0x0028, 0x007A, 0x0029},
+	0x24B6: {0x0041},
+	0x24B7: {0x0042},
+	0x24B8: {0x0043},
+	0x24B9: {0x0044},
+	0x24BA: {0x0045},
+	0x24BB: {0x0046},
+	0x24BC: {0x0047},
+	0x24BD: {0x0048},
+	0x24BE: {0x0049},
+	0x24BF: {0x004A},
+	0x24C0: {0x004B},
+	0x24C1: {0x004C},
+	0x24C2: {0x004D},
+	0x24C3: {0x004E},
+	0x24C4: {0x004F},
+	0x24C5: {0x0050},
+	0x24C6: {0x0051},
+	0x24C7: {0x0052},
+	0x24C8: {0x0053},
+	0x24C9: {0x0054},
+	0x24CA: {0x0055},
+	0x24CB: {0x0056},
+	0x24CC: {0x0057},
+	0x24CD: {0x0058},
+	0x24CE: {0x0059},
+	0x24CF: {0x005A},
+	0x24D0: {0x0061},
+	0x24D1: {0x0062},
+	0x24D2: {0x0063},
+	0x24D3: {0x0064},
+	0x24D4: {0x0065},
+	0x24D5: {0x0066},
+	0x24D6: {0x0067},
+	0x24D7: {0x0068},
+	0x24D8: {0x0069},
+	0x24D9: {0x006A},
+	0x24DA: {0x006B},
+	0x24DB: {0x006C},
+	0x24DC: {0x006D},
+	0x24DD: {0x006E},
+	0x24DE: {0x006F},
+	0x24DF: {0x0070},
+	0x24E0: {0x0071},
+	0x24E1: {0x0072},
+	0x24E2: {0x0073},
+	0x24E3: {0x0074},
+	0x24E4: {0x0075},
+	0x24E5: {0x0076},
+	0x24E6: {0x0077},
+	0x24E7: {0x0078},
+	0x24E8: {0x0079},
+	0x24E9: {0x007A},
+	0x24EA: {0x0030},
+	0x3000: {0x0020},
+	0xFB00: {0x0066, 0x0066},
+	0xFB01: {0x0066, 0x0069},
+	0xFB02: {0x0066, 0x006C},
+	0xFB03: {0x0066, 0x0066, 0x0069},
+	0xFB04: {0x0066, 0x0066, 0x006C},
+	0xFB05: {0x017F, 0x0074},
+	0xFB06: {0x0073, 0x0074},
+	0xFF01: {0x0021},
+	0xFF02: {0x0022},
+	0xFF03: {0x0023},
+	0xFF04: {0x0024},
+	0xFF05: {0x0025},
+	0xFF06: {0x0026},
+	0xFF07: {0x0027},
+	0xFF08: {0x0028},
+	0xFF09: {0x0029},
+	0xFF0A: {0x002A},
+	0xFF0B: {0x002B},
+	0xFF0C: {0x002C},
+	0xFF0D: {0x002D},
+	0xFF0E: {0x002E},
+	0xFF0F: {0x002F},
+	0xFF10: {0x0030},
+	0xFF11: {0x0031},
+	0xFF12: {0x0032},
+	0xFF13: {0x0033},
+	0xFF14: {0x0034},
+	0xFF15: {0x0035},
+	0xFF16: {0x0036},
+	0xFF17: {0x0037},
+	0xFF18: {0x0038},
+	0xFF19: {0x0039},
+	0xFF1A: {0x003A},
+	0xFF1B: {0x003B},
+	0xFF1C: {0x003C},
+	0xFF1D: {0x003D},
+	0xFF1E: {0x003E},
+	0xFF1F: {0x003F},
+	0xFF20: {0x0040},
+	0xFF21: {0x0041},
+	0xFF22: {0x0042},
+	0xFF23: {0x0043},
+	0xFF24: {0x0044},
+	0xFF25: {0x0045},
+	0xFF26: {0x0046},
+	0xFF27: {0x0047},
+	0xFF28: {0x0048},
+	0xFF29: {0x0049},
+	0xFF2A: {0x004A},
+	0xFF2B: {0x004B},
+	0xFF2C: {0x004C},
+	0xFF2D: {0x004D},
+	0xFF2E: {0x004E},
+	0xFF2F: {0x004F},
+	0xFF30: {0x0050},
+	0xFF31: {0x0051},
+	0xFF32: {0x0052},
+	0xFF33: {0x0053},
+	0xFF34: {0x0054},
+	0xFF35: {0x0055},
+	0xFF36: {0x0056},
+	0xFF37: {0x0057},
+	0xFF38: {0x0058},
+	0xFF39: {0x0059},
+	0xFF3A: {0x005A},
+	0xFF3B: {0x005B},
+	0xFF3C: {0x005C},
+	0xFF3D: {0x005D},
+	0xFF3E: {0x005E},
+	0xFF3F: {0x005F},
+	0xFF40: {0x0060},
+	0xFF41: {0x0061},
+	0xFF42: {0x0062},
+	0xFF43: {0x0063},
+	0xFF44: {0x0064},
+	0xFF45: {0x0065},
+	0xFF46: {0x0066},
+	0xFF47: {0x0067},
+	0xFF48: {0x0068},
+	0xFF49: {0x0069},
+	0xFF4A: {0x006A},
+	0xFF4B: {0x006B},
+	0xFF4C: {0x006C},
+	0xFF4D: {0x006D},
+	0xFF4E: {0x006E},
+	0xFF4F: {0x006F},
+	0xFF50: {0x0070},
+	0xFF51: {0x0071},
+	0xFF52: {0x0072},
+	0xFF53: {0x0073},
+	0xFF54: {0x0074},
+	0xFF55: {0x0075},
+	0xFF56: {0x0076},
+	0xFF57: {0x0077},
+	0xFF58: {0x0078},
+	0xFF59: {0x0079},
+	0xFF5A: {0x007A},
+	0xFF5B: {0x007B},
+	0xFF5C: {0x007C},
+	0xFF5D: {0x007D},
+	0xFF5E: {0x007E},
+	0xFF5F: {0x2985},
+	0xFF60: {0x2986},
+	0xFF61: {0x3002},
+	0xFF62: {0x300C},
+	0xFF63: {0x300D},
+	0xFF64: {0x3001},
+	0xFF65: {0x30FB},
+	0xFF66: {0x30F2},
+	0xFF67: {0x30A1},
+	0xFF68: {0x30A3},
+	0xFF69: {0x30A5},
+	0xFF6A: {0x30A7},
+	0xFF6B: {0x30A9},
+	0xFF6C: {0x30E3},
+	0xFF6D: {0x30E5},
+	0xFF6E: {0x30E7},
+	0xFF6F: {0x30C3},
+	0xFF70: {0x30FC},
+	0xFF71: {0x30A2},
+	0xFF72: {0x30A4},
+	0xFF73: {0x30A6},
+	0xFF74: {0x30A8},
+	0xFF75: {0x30AA},
+	0xFF76: {0x30AB},
+	0xFF77: {0x30AD},
+	0xFF78: {0x30AF},
+	0xFF79: {0x30B1},
+	0xFF7A: {0x30B3},
+	0xFF7B: {0x30B5},
+	0xFF7C: {0x30B7},
+	0xFF7D: {0x30B9},
+	0xFF7E: {0x30BB},
+	0xFF7F: {0x30BD},
+	0xFF80: {0x30BF},
+	0xFF81: {0x30C1},
+	0xFF82: {0x30C4},
+	0xFF83: {0x30C6},
+	0xFF84: {0x30C8},
+	0xFF85: {0x30CA},
+	0xFF86: {0x30CB},
+	0xFF87: {0x30CC},
+	0xFF88: {0x30CD},
+	0xFF89: {0x30CE},
+	0xFF8A: {0x30CF},
+	0xFF8B: {0x30D2},
+	0xFF8C: {0x30D5},
+	0xFF8D: {0x30D8},
+	0xFF8E: {0x30DB},
+	0xFF8F: {0x30DE},
+	0xFF90: {0x30DF},
+	0xFF91: {0x30E0},
+	0xFF92: {0x30E1},
+	0xFF93: {0x30E2},
+	0xFF94: {0x30E4},
+	0xFF95: {0x30E6},
+	0xFF96: {0x30E8},
+	0xFF97: {0x30E9},
+	0xFF98: {0x30EA},
+	0xFF99: {0x30EB},
+	0xFF9A: {0x30EC},
+	0xFF9B: {0x30ED},
+	0xFF9C: {0x30EF},
+	0xFF9D: {0x30F3},
+	0xFF9E: {0x3099},
+	0xFF9F: {0x309A},
+	0xFFA0: {0x3164},
+	0xFFA1: {0x3131},
+	0xFFA2: {0x3132},
+	0xFFA3: {0x3133},
+	0xFFA4: {0x3134},
+	0xFFA5: {0x3135},
+	0xFFA6: {0x3136},
+	0xFFA7: {0x3137},
+	0xFFA8: {0x3138},
+	0xFFA9: {0x3139},
+	0xFFAA: {0x313A},
+	0xFFAB: {0x313B},
+	0xFFAC: {0x313C},
+	0xFFAD: {0x313D},
+	0xFFAE: {0x313E},
+	0xFFAF: {0x313F},
+	0xFFB0: {0x3140},
+	0xFFB1: {0x3141},
+	0xFFB2: {0x3142},
+	0xFFB3: {0x3143},
+	0xFFB4: {0x3144},
+	0xFFB5: {0x3145},
+	0xFFB6: {0x3146},
+	0xFFB7: {0x3147},
+	0xFFB8: {0x3148},
+	0xFFB9: {0x3149},
+	0xFFBA: {0x314A},
+	0xFFBB: {0x314B},
+	0xFFBC: {0x314C},
+	0xFFBD: {0x314D},
+	0xFFBE: {0x314E},
+	0xFFC2: {0x314F},
+	0xFFC3: {0x3150},
+	0xFFC4: {0x3151},
+	0xFFC5: {0x3152},
+	0xFFC6: {0x3153},
+	0xFFC7: {0x3154},
+	0xFFCA: {0x3155},
+	0xFFCB: {0x3156},
+	0xFFCC: {0x3157},
+	0xFFCD: {0x3158},
+	0xFFCE: {0x3159},
+	0xFFCF: {0x315A},
+	0xFFD2: {0x315B},
+	0xFFD3: {0x315C},
+	0xFFD4: {0x315D},
+	0xFFD5: {0x315E},
+	0xFFD6: {0x315F},
+	0xFFD7: {0x3160},
+	0xFFDA: {0x3161},
+	0xFFDB: {0x3162},
+	0xFFDC: {0x3163},
+	0xFFE0: {0x00A2},
+	0xFFE1: {0x00A3},
+	0xFFE2: {0x00AC},
+	0xFFE3: {0x00AF},
+	0xFFE4: {0x00A6},
+	0xFFE5: {0x00A5},
+	0xFFE6: {0x20A9},
+	0xFFE8: {0x2502},
+	0xFFE9: {0x2190},
+	0xFFEA: {0x2191},
+	0xFFEB: {0x2192},
+	0xFFEC: {0x2193},
+	0xFFED: {0x25A0},
+	0xFFEE: {0x25CB},
+}
+
+// Canonical combining classes of combining marks.
+var combiningClasses = map[rune]uint8{
+	0x0300: 230,
+	0x0301: 230,
+	0x0302: 230,
+	0x0303: 230,
+	0x0304: 230,
+	0x0305: 230,
+	0x0306: 230,
+	0x0307: 230,
+	0x0308: 230,
+	0x0309: 230,
+	0x030A: 230,
+	0x030B: 230,
+	0x030C: 230,
+	0x030D: 230,
+	0x030E: 230,
+	0x030F: 230,
+	0x0310: 230,
+	0x0311: 230,
+	0x0312: 230,
+	0x0313: 230,
+	0x0314: 230,
+	0x0315: 232,
+	0x0316: 220,
+	0x0317: 220,
+	0x0318: 220,
+	0x0319: 220,
+	0x031A: 232,
+	0x031B: 216,
+	0x031C: 220,
+	0x031D: 220,
+	0x031E: 220,
+	0x031F: 220,
+	0x0320: 220,
+	0x0321: 202,
+	0x0322: 202,
+	0x0323: 220,
+	0x0324: 220,
+	0x0325: 220,
+	0x0326: 220,
+	0x0327: 202,
+	0x0328: 202,
+	0x0329: 220,
+	0x032A: 220,
+	0x032B: 220,
+	0x032C: 220,
+	0x032D: 220,
+	0x032E: 220,
+	0x032F: 220,
+	0x0330: 220,
+	0x0331: 220,
+	0x0332: 220,
+	0x0333: 220,
+	0x0334: 1,
+	0x0335: 1,
+	0x0336: 1,
+	0x0337: 1,
+	0x0338: 1,
+	0x0339: 220,
+	0x033A: 220,
+	0x033B: 220,
+	0x033C: 220,
+	0x033D: 230,
+	0x033E: 230,
+	0x033F: 230,
+	0x0340: 230,
+	0x0341: 230,
+	0x0342: 230,
+	0x0343: 230,
+	0x0344: 230,
+	0x0345: 240,
+	0x0346: 230,
+	0x0347: 220,
+	0x0348: 220,
+	0x0349: 220,
+	0x034A: 230,
+	0x034B: 230,
+	0x034C: 230,
+	0x034D: 220,
+	0x034E: 220,
+	0x0350: 230,
+	0x0351: 230,
+	0x0352: 230,
+	0x0353: 220,
+	0x0354: 220,
+	0x0355: 220,
+	0x0356: 220,
+	0x0357: 230,
+	0x0358: 232,
+	0x0359: 220,
+	0x035A: 220,
+	0x035B: 230,
+	0x035C: 233,
+	0x035D: 234,
+	0x035E: 234,
+	0x035F: 233,
+	0x0360: 234,
+	0x0361: 234,
+	0x0362: 233,
+	0x0363: 230,
+	0x0364: 230,
+	0x0365: 230,
+	0x0366: 230,
+	0x0367: 230,
+	0x0368: 230,
+	0x0369: 230,
+	0x036A: 230,
+	0x036B: 230,
+	0x036C: 230,
+	0x036D: 230,
+	0x036E: 230,
+	0x036F: 230,
+	0x0483: 230,
+	0x0484: 230,
+	0x0485: 230,
+	0x0486: 230,
+	0x0487: 230,
+	0x3099: 8,
+	0x309A: 8,
+}
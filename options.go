@@ -0,0 +1,173 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A NormalizationForm names a Unicode normalization form applied to patterns and input.
+type NormalizationForm int64
+
+const (
+	NoNormalization NormalizationForm = iota // Patterns and input are matched as is.
+	FormNFC                                  // See NFC.
+	FormNFKC                                 // See NFKC.
+)
+
+// Parse the name of a NormalizationForm: "none" (or ""), "NFC" or "NFKC", ignoring case.
+func ParseNormalizationForm(name string) (NormalizationForm, error) {
+	switch strings.ToUpper(name) {
+	case "", "NONE":
+		return NoNormalization, nil
+	case "NFC":
+		return FormNFC, nil
+	case "NFKC":
+		return FormNFKC, nil
+	}
+	return NoNormalization, fmt.Errorf("Unknown normalization form %q.", name)
+}
+
+func (form NormalizationForm) String() string {
+	switch form {
+	case NoNormalization:
+		return "none"
+	case FormNFC:
+		return "NFC"
+	case FormNFKC:
+		return "NFKC"
+	}
+	return "NormalizationForm(?)"
+}
+
+// A MatchKind decides which matches are reported.
+type MatchKind int64
+
+const (
+	MatchAll            MatchKind = iota // Every match, including overlapping ones.
+	MatchNonOverlapping                  // Matches resolved by priority (see Matcher.NonOverlapping).
+)
+
+func (kind MatchKind) String() string {
+	switch kind {
+	case MatchAll:
+		return "all"
+	case MatchNonOverlapping:
+		return "non-overlapping"
+	}
+	return "MatchKind(?)"
+}
+
+// Options configure how a Trie matches (see NewTrieBuilderWithOptions). They are kept by the Trie,
+// and saved by SaveTrie and GenerateGo, so a loaded Trie matches the same way as the one saved.
+type Options struct {
+	CaseFolding   bool              // Whether to match case-insensitively (see FoldCase).
+	Normalization NormalizationForm // The normalization form of patterns and input.
+	MatchKind     MatchKind         // Which matches to report.
+}
+
+// An Option sets one of the Options.
+type Option func(*Options)
+
+// Toggle case-insensitive matching.
+func WithCaseFolding(b bool) Option {
+	return func(o *Options) { o.CaseFolding = b }
+}
+
+// Set the normalization form of patterns and input.
+func WithNormalization(form NormalizationForm) Option {
+	return func(o *Options) { o.Normalization = form }
+}
+
+// Set which matches to report.
+func WithMatchKind(kind MatchKind) Option {
+	return func(o *Options) { o.MatchKind = kind }
+}
+
+// Create and initialize a new TrieBuilder, with options for the Trie it builds. Invalid options
+// (e.g. an unknown normalization form) are the error of the TrieBuilder (see Err), and no patterns
+// are added.
+func NewTrieBuilderWithOptions(opts ...Option) *TrieBuilder {
+	tb := NewTrieBuilder()
+
+	for _, opt := range opts {
+		opt(&tb.options)
+	}
+
+	tb.err = tb.options.validate()
+	tb.transform = tb.options.transform()
+
+	return tb
+}
+
+// Set options of a Trie created with NewTrie (e.g. by code generated by GenerateGo). Returns the
+// Trie. Invalid options (e.g. an unknown normalization form) are ignored, keeping the options of
+// the Trie unchanged.
+func (tr *Trie) WithOptions(opts ...Option) *Trie {
+	options := tr.options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.validate() != nil {
+		return tr
+	}
+
+	tr.options = options
+	tr.transform = tr.options.transform()
+
+	return tr
+}
+
+// Get the options of the Trie.
+func (tr *Trie) Options() Options {
+	return tr.options
+}
+
+// Get the Transform implementing the options, or nil if there is nothing to transform.
+func (o Options) transform() Transform {
+	var norm Transform
+	switch o.Normalization {
+	case FormNFC:
+		norm = NFC
+	case FormNFKC:
+		norm = NFKC
+	}
+
+	switch {
+	case !o.CaseFolding:
+		return norm
+	case norm == nil:
+		return FoldCase
+	}
+
+	return func(b []byte) []byte { return FoldCase(norm(b)) }
+}
+
+// Check that the options are known, e.g. when read from a file.
+func (o Options) validate() error {
+	if o.Normalization < NoNormalization || o.Normalization > FormNFKC {
+		return fmt.Errorf("Unknown normalization form %d.", o.Normalization)
+	}
+	if o.MatchKind < MatchAll || o.MatchKind > MatchNonOverlapping {
+		return fmt.Errorf("Unknown match kind %d.", o.MatchKind)
+	}
+	return nil
+}
+
+// Get the options differing from the defaults as Go code, as arguments to Trie.WithOptions (for
+// GenerateGo).
+func (o Options) goCode() []string {
+	code := make([]string, 0)
+
+	if o.CaseFolding {
+		code = append(code, "ahocorasick.WithCaseFolding(true)")
+	}
+	if o.Normalization != NoNormalization {
+		code = append(code, fmt.Sprintf("ahocorasick.WithNormalization(ahocorasick.Form%s)", o.Normalization))
+	}
+	if o.MatchKind == MatchNonOverlapping {
+		code = append(code, "ahocorasick.WithMatchKind(ahocorasick.MatchNonOverlapping)")
+	}
+
+	return code
+}
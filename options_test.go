@@ -0,0 +1,190 @@
+package ahocorasick
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFoldCase(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"Hello, World!", "HELLO, WORLD!"},
+		{"straße", "STRAßE"},
+		{"KéÉ", "KÉÉ"},
+		{"a\xffb", "A\xffB"},
+	}
+
+	for _, c := range cases {
+		if got := string(FoldCase([]byte(c.input))); got != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.input, c.expected, got)
+		}
+	}
+}
+
+func TestParseNormalizationForm(t *testing.T) {
+	for _, form := range []NormalizationForm{NoNormalization, FormNFC, FormNFKC} {
+		if parsed, err := ParseNormalizationForm(form.String()); err != nil || parsed != form {
+			t.Errorf("%s: expected %s, got %s (%v)", form, form, parsed, err)
+		}
+	}
+
+	if _, err := ParseNormalizationForm("NFD"); err == nil {
+		t.Error("expected error for NFD")
+	}
+}
+
+func TestTrieBuilderWithOptions(t *testing.T) {
+	cases := []struct {
+		name     string
+		trie     *Trie
+		input    string
+		expected string
+	}{
+		{
+			"CaseFolding",
+			NewTrieBuilderWithOptions(WithCaseFolding(true)).AddStrings([]string{"Hello", "WORLD"}).Build(),
+			"hello World",
+			`[{0 "hello"} {6 "World"}]`,
+		},
+		{
+			"Normalization",
+			NewTrieBuilderWithOptions(WithNormalization(FormNFC), WithCaseFolding(true)).AddString("Café").Build(),
+			"CAFÉ cafe",
+			`[{0 "CAFÉ"}]`,
+		},
+		{
+			"MatchKind",
+			NewTrieBuilderWithOptions(WithMatchKind(MatchNonOverlapping)).AddStrings([]string{"he", "she", "hers"}).Build(),
+			"ushers",
+			`[{2 "hers"}]`,
+		},
+	}
+
+	for _, c := range cases {
+		if got := fmt.Sprint(c.trie.MatchString(c.input)); got != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, got)
+		}
+
+		if got := fmt.Sprint(NewMatcher(c.trie).MatchString(c.input)); got != c.expected {
+			t.Errorf("%s: expected %s from Matcher, got %s", c.name, c.expected, got)
+		}
+
+		matches := c.trie.MatchString(c.input)
+
+		if n := c.trie.CountString(c.input); n != int64(len(matches)) {
+			t.Errorf("%s: expected count %d, got %d", c.name, len(matches), n)
+		}
+
+		if got := fmt.Sprint(c.trie.MatchStringFirst(c.input)); got != fmt.Sprint(matches[0]) {
+			t.Errorf("%s: expected first match %v, got %s", c.name, matches[0], got)
+		}
+
+		if !c.trie.HasMatchString(c.input) || c.trie.HasMatchString("nothing") {
+			t.Errorf("%s: expected HasMatch to agree with Match", c.name)
+		}
+	}
+
+	trie := cases[2].trie
+	if n := trie.CountString("ushers"); n != 1 {
+		t.Errorf("expected count 1, got %d", n)
+	}
+
+	// An explicit Transform replaces the one given by the options.
+	tb := NewTrieBuilderWithOptions(WithCaseFolding(true)).Transform(nil)
+	if o := tb.Build().Options(); o.CaseFolding {
+		t.Errorf("expected case folding to be replaced, got %+v", o)
+	}
+}
+
+func TestSaveTrieOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ahocorasick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "options.trie")
+
+	trie := NewTrieBuilderWithOptions(WithCaseFolding(true), WithNormalization(FormNFKC), WithMatchKind(MatchNonOverlapping)).
+		AddStrings([]string{"he", "she", "hers", "ﬁle"}).
+		Build()
+
+	if err := SaveTrie(trie, path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadTrie(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Options() != trie.Options() {
+		t.Errorf("expected options %+v, got %+v", trie.Options(), loaded.Options())
+	}
+
+	input := "USHERS File"
+	if got, expected := fmt.Sprint(loaded.MatchString(input)), fmt.Sprint(trie.MatchString(input)); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Newer versions are rejected.
+	version := -(FormatVersion + 1)
+	binary.LittleEndian.PutUint64(data[4:], uint64(version))
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadTrie(path); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
+
+func TestInvalidOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ahocorasick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "invalid.trie")
+
+	for _, opt := range []Option{WithNormalization(NormalizationForm(7)), WithMatchKind(MatchKind(-1))} {
+		tb := NewTrieBuilderWithOptions(opt).AddString("he")
+		if tb.Err() == nil {
+			t.Errorf("expected error for options %+v", tb.options)
+		}
+
+		trie := tb.Build()
+		if trie.NumPatterns() != 0 {
+			t.Errorf("expected no patterns, got %d", trie.NumPatterns())
+		}
+
+		if tb.Reset().AddString("he").Err() == nil {
+			t.Errorf("expected error for options %+v after Reset", tb.options)
+		}
+
+		// A trie with invalid options (e.g. created with NewTrie) is not saved.
+		trie.options = tb.options
+		if err := SaveTrie(trie, path); err == nil {
+			t.Errorf("expected error saving options %+v", trie.options)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected no file to be written, got %v", err)
+		}
+
+		trie = NewTrieBuilder().AddString("he").Build().WithOptions(WithCaseFolding(true), opt)
+		if trie.Options() != (Options{}) {
+			t.Errorf("expected invalid options to be ignored, got %+v", trie.Options())
+		}
+	}
+}
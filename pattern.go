@@ -2,6 +2,8 @@ package ahocorasick
 
 import (
 	"bufio"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"strings"
 )
@@ -29,8 +31,81 @@ func ReadStrings(path string) ([][]byte, error) {
 	return patterns, nil
 }
 
-// Read patterns in hex format, one pattern on each line.
+// Read patterns in hex format, one pattern on each line. Whitespace between the hex digits is
+// ignored, so both "4d5a90" and "4d 5a 90" are accepted.
 func ReadHex(path string) ([][]byte, error) {
-	// TODO: implement
-	return nil, nil
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	patterns := make([][]byte, 0)
+
+	for n := 1; s.Scan(); n++ {
+		pattern, err := hex.DecodeString(strings.Join(strings.Fields(s.Text()), ""))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, n, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
+
+// Read WildcardPatterns (see ParseWildcard), one pattern on each line.
+func ReadWildcards(path string) ([]WildcardPattern, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	patterns := make([]WildcardPattern, 0)
+
+	for n := 1; s.Scan(); n++ {
+		pattern, err := ParseWildcard(s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, n, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
+
+// Read HexStrings (see ParseHexString), one pattern on each line.
+func ReadHexStrings(path string) ([]*HexString, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	patterns := make([]*HexString, 0)
+
+	for n := 1; s.Scan(); n++ {
+		pattern, err := ParseHexString(s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, n, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
 }
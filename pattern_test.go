@@ -16,3 +16,60 @@ func TestReadStrings(t *testing.T) {
 		t.Errorf("expected %q, got %q", "abandonerende", patterns[7])
 	}
 }
+
+func TestReadHex(t *testing.T) {
+	patterns, err := ReadHex("./test_data/hex.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"MZ", "PK\x03\x04", "\x7fELF"}
+
+	if len(patterns) != len(expected) {
+		t.Fatalf("expected %d patterns, got %d", len(expected), len(patterns))
+	}
+
+	for i := range expected {
+		if string(patterns[i]) != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], patterns[i])
+		}
+	}
+}
+
+func TestReadWildcards(t *testing.T) {
+	patterns, err := ReadWildcards("./test_data/wildcards.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"4D 5A ?? 00", "[50|52] 4B 03 04", "7F 45 4C 46"}
+
+	if len(patterns) != len(expected) {
+		t.Fatalf("expected %d patterns, got %d", len(expected), len(patterns))
+	}
+
+	for i := range expected {
+		if patterns[i].String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], patterns[i])
+		}
+	}
+}
+
+func TestReadHexStrings(t *testing.T) {
+	patterns, err := ReadHexStrings("./test_data/hexstrings.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"{ 4D 5A [2-4] 50 45 }", "{ 50 4B ( 03 04 | 05 06 ) }", "{ 7F 45 4C 46 0? }"}
+
+	if len(patterns) != len(expected) {
+		t.Fatalf("expected %d patterns, got %d", len(expected), len(patterns))
+	}
+
+	for i := range expected {
+		if patterns[i].String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], patterns[i])
+		}
+	}
+}
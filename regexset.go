@@ -0,0 +1,358 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"unicode"
+)
+
+// The maximum number of strings in a literal set extracted from a regex.
+const maxRegexLiterals = 16
+
+// A RegexSet matches a number of regexes against input, using a Trie of literals extracted from
+// the regexes as a prefilter: a regex is only run if one of its required literals occurs in the
+// input (or if no literals could be extracted from it).
+type RegexSet struct {
+	trie    *Trie            // Matches the literals.
+	regexps []*regexp.Regexp // The regexes, indexed by ID.
+	refs    [][]int64        // The IDs of the regexes requiring each literal, indexed by literal ID.
+	always  []int64          // The IDs of the regexes without required literals.
+}
+
+// Compile a RegexSet from regexes in the syntax of package regexp. The regexes are given IDs equal
+// to their index in exprs (see Match.ID).
+func NewRegexSet(exprs []string) (*RegexSet, error) {
+	rs := &RegexSet{
+		regexps: make([]*regexp.Regexp, 0, len(exprs)),
+		refs:    make([][]int64, 0),
+		always:  make([]int64, 0),
+	}
+
+	tb := NewTrieBuilder()
+	literalIDs := make(map[string]int64)
+
+	for i, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid regex %q: %v", expr, err)
+		}
+
+		tree, err := syntax.Parse(expr, syntax.Perl)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid regex %q: %v", expr, err)
+		}
+
+		rs.regexps = append(rs.regexps, re)
+
+		literals := RequiredLiterals(tree.Simplify())
+		if literals == nil {
+			rs.always = append(rs.always, int64(i))
+			continue
+		}
+
+		for _, lit := range literals {
+			id, ok := literalIDs[lit]
+			if !ok {
+				id = int64(len(rs.refs))
+				literalIDs[lit] = id
+				rs.refs = append(rs.refs, nil)
+				tb.AddPatternWithID([]byte(lit), id)
+			}
+			rs.refs[id] = append(rs.refs[id], int64(i))
+		}
+	}
+
+	rs.trie = tb.Build()
+
+	return rs, nil
+}
+
+// Get the IDs of the regexes whose literals occur in input (or which have none), in increasing
+// order. Only these regexes can match input.
+func (rs *RegexSet) candidates(input []byte) []int64 {
+	fired := make([]bool, len(rs.regexps))
+	for _, id := range rs.always {
+		fired[id] = true
+	}
+
+	for _, lit := range rs.trie.Match(input) {
+		for _, id := range rs.refs[lit.ID()] {
+			fired[id] = true
+		}
+	}
+
+	ids := make([]int64, 0)
+	for id, ok := range fired {
+		if ok {
+			ids = append(ids, int64(id))
+		}
+	}
+
+	return ids
+}
+
+// Get the IDs of the regexes matching input, in increasing order.
+func (rs *RegexSet) MatchingIDs(input []byte) []int64 {
+	ids := make([]int64, 0)
+
+	for _, id := range rs.candidates(input) {
+		if rs.regexps[id].Match(input) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// Run the RegexSet against the provided input and return the (non-overlapping) matches of each
+// regex, ordered by their end position. Match.ID returns the ID of the regex.
+func (rs *RegexSet) Match(input []byte) []*Match {
+	matches := make([]*Match, 0)
+
+	for _, id := range rs.candidates(input) {
+		for _, loc := range rs.regexps[id].FindAllIndex(input, -1) {
+			matches = append(matches, newMatch(int64(loc[0]), id, input[loc[0]:loc[1]]))
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].End() < matches[j].End()
+	})
+
+	return matches
+}
+
+// Helper method to make matching strings a little more comfortable.
+func (rs *RegexSet) MatchString(input string) []*Match {
+	return rs.Match([]byte(input))
+}
+
+// Helper method to make checking which regexes match a string a little more comfortable.
+func (rs *RegexSet) MatchingIDsString(input string) []int64 {
+	return rs.MatchingIDs([]byte(input))
+}
+
+// Extract a set of literals from a (simplified) regex, at least one of which occurs in every match
+// of the regex. Returns nil if there is no such set, e.g. for "a*" or ".+".
+func RequiredLiterals(re *syntax.Regexp) []string {
+	info := analyzeRegex(re)
+
+	lits := info.required()
+	if lits == nil || minLen(lits) == 0 {
+		return nil
+	}
+
+	sort.Strings(lits)
+	return lits
+}
+
+// What is known about the strings matched by (part of) a regex.
+type regexInfo struct {
+	exact []string // The strings matched, if they are few enough to list, otherwise nil.
+	req   []string // Strings of which one occurs in every match, or nil if there are none.
+}
+
+// Get the strings of which one occurs in every match.
+func (info regexInfo) required() []string {
+	if info.exact != nil {
+		return info.exact
+	}
+	return info.req
+}
+
+func analyzeRegex(re *syntax.Regexp) regexInfo {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText,
+		syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return regexInfo{exact: []string{""}}
+
+	case syntax.OpLiteral:
+		return literalInfo(re.Rune, re.Flags&syntax.FoldCase != 0)
+
+	case syntax.OpCharClass:
+		var n int
+		for i := 0; i < len(re.Rune); i += 2 {
+			n += int(re.Rune[i+1]-re.Rune[i]) + 1
+			if n > maxRegexLiterals {
+				return regexInfo{}
+			}
+		}
+
+		exact := make([]string, 0, n)
+		for i := 0; i < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				exact = append(exact, string(r))
+			}
+		}
+		return regexInfo{exact: exact}
+
+	case syntax.OpCapture:
+		return analyzeRegex(re.Sub[0])
+
+	case syntax.OpPlus:
+		return regexInfo{req: analyzeRegex(re.Sub[0]).required()}
+
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return regexInfo{}
+		}
+		return regexInfo{req: analyzeRegex(re.Sub[0]).required()}
+
+	case syntax.OpConcat:
+		return concatInfo(re.Sub)
+
+	case syntax.OpAlternate:
+		return alternateInfo(re.Sub)
+	}
+
+	// OpAnyChar, OpAnyCharNotNL, OpStar, OpQuest and OpNoMatch: nothing is required.
+	return regexInfo{}
+}
+
+// Get the info of a literal, expanding case variants if folding case. If there are too many
+// variants, only the longest prefix with few enough variants is required.
+func literalInfo(runes []rune, fold bool) regexInfo {
+	exact := []string{""}
+
+	for _, r := range runes {
+		variants := []rune{r}
+		if fold {
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				variants = append(variants, f)
+			}
+		}
+
+		if len(exact)*len(variants) > maxRegexLiterals {
+			return regexInfo{req: exact}
+		}
+
+		next := make([]string, 0, len(exact)*len(variants))
+		for _, s := range exact {
+			for _, v := range variants {
+				next = append(next, s+string(v))
+			}
+		}
+		exact = next
+	}
+
+	return regexInfo{exact: exact}
+}
+
+// Combine the infos of a concatenation. Runs of exact parts are multiplied out, and the best of
+// the resulting requirements is kept.
+func concatInfo(subs []*syntax.Regexp) regexInfo {
+	run := []string{""} // The exact strings of the current run.
+	var best []string
+	broken := false
+
+	for _, sub := range subs {
+		info := analyzeRegex(sub)
+
+		if info.exact != nil && len(run)*len(info.exact) <= maxRegexLiterals {
+			run = crossStrings(run, info.exact)
+			continue
+		}
+
+		broken = true
+		best = betterLiterals(best, run)
+		best = betterLiterals(best, info.required())
+
+		run = []string{""}
+		if info.exact != nil {
+			run = info.exact
+		}
+	}
+
+	if !broken {
+		return regexInfo{exact: run}
+	}
+
+	return regexInfo{req: betterLiterals(best, run)}
+}
+
+// Combine the infos of an alternation: one of the requirements of the matching alternative occurs.
+func alternateInfo(subs []*syntax.Regexp) regexInfo {
+	exact := make([]string, 0)
+	req := make([]string, 0)
+
+	for _, sub := range subs {
+		info := analyzeRegex(sub)
+
+		if exact != nil && info.exact != nil && len(exact)+len(info.exact) <= maxRegexLiterals {
+			exact = append(exact, info.exact...)
+		} else {
+			exact = nil
+		}
+
+		lits := info.required()
+		if lits == nil || req == nil {
+			req = nil
+		} else {
+			req = append(req, lits...)
+		}
+	}
+
+	if exact != nil {
+		return regexInfo{exact: dedupStrings(exact)}
+	}
+
+	if req == nil || len(req) > maxRegexLiterals {
+		return regexInfo{}
+	}
+
+	return regexInfo{req: dedupStrings(req)}
+}
+
+// Choose the more selective of two literal sets: the one with the longer shortest string, then the
+// one with fewer strings.
+func betterLiterals(a, b []string) []string {
+	switch {
+	case b == nil:
+		return a
+	case a == nil:
+		return b
+	case minLen(a) != minLen(b):
+		if minLen(b) > minLen(a) {
+			return b
+		}
+		return a
+	case len(b) < len(a):
+		return b
+	}
+	return a
+}
+
+// Get the length of the shortest string (in bytes), or 0 if there are none.
+func minLen(lits []string) int {
+	n := 0
+	for i, lit := range lits {
+		if i == 0 || len(lit) < n {
+			n = len(lit)
+		}
+	}
+	return n
+}
+
+func crossStrings(a, b []string) []string {
+	out := make([]string, 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			out = append(out, x+y)
+		}
+	}
+	return dedupStrings(out)
+}
+
+func dedupStrings(lits []string) []string {
+	seen := make(map[string]bool)
+	out := make([]string, 0, len(lits))
+	for _, lit := range lits {
+		if !seen[lit] {
+			seen[lit] = true
+			out = append(out, lit)
+		}
+	}
+	return out
+}
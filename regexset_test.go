@@ -0,0 +1,85 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestRequiredLiterals(t *testing.T) {
+	cases := []struct {
+		expr     string
+		expected string
+	}{
+		{`hello`, `[hello]`},
+		{`hello.*world`, `[hello]`},
+		{`a.*world`, `[world]`},
+		{`(foo|bar)baz`, `[barbaz foobaz]`},
+		{`(foo|bar).*x`, `[bar foo]`},
+		{`error [0-9]+`, `[error ]`},
+		{`error [0-9]`, `[error 0 error 1 error 2 error 3 error 4 error 5 error 6 error 7 error 8 error 9]`},
+		{`(?i)err`, `[ERR ERr ErR Err eRR eRr erR err]`},
+		{`(?i)error`, `[ERRO ERRo ERrO ERro ErRO ErRo ErrO Erro eRRO eRRo eRrO eRro erRO erRo errO erro]`},
+		{`x+y`, `[x]`},
+		{`(abc)+`, `[abc]`},
+		{`a*`, `[]`},
+		{`.+`, `[]`},
+		{`foo|.*`, `[]`},
+		{`^GET /\w+`, `[GET /]`},
+		{`[ab][cd]`, `[ac ad bc bd]`},
+		{`user=(\w+) id=\d+`, `[user=]`},
+		{`^\d{4}-\d\d`, `[-0 -1 -2 -3 -4 -5 -6 -7 -8 -9]`},
+	}
+
+	for _, c := range cases {
+		re, err := syntax.Parse(c.expr, syntax.Perl)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := fmt.Sprint(RequiredLiterals(re.Simplify())); got != c.expected {
+			t.Errorf("%q: expected %s, got %s", c.expr, c.expected, got)
+		}
+	}
+}
+
+func TestRegexSet(t *testing.T) {
+	rs, err := NewRegexSet([]string{
+		`error code=\d+`,
+		`(?i)timeout`,
+		`user=(alice|bob)`,
+		`^\d{4}-\d\d-\d\d`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line := "2019-03-01 ERROR: Timeout for user=bob (error code=42, error code=7)"
+
+	expected := `[{0 "2019-03-01"} {18 "Timeout"} {30 "user=bob"} {40 "error code=42"} {55 "error code=7"}]`
+	if got := fmt.Sprint(rs.MatchString(line)); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if got := fmt.Sprint(rs.MatchingIDsString(line)); got != "[0 1 2 3]" {
+		t.Errorf("expected IDs [0 1 2 3], got %s", got)
+	}
+
+	if got := fmt.Sprint(rs.MatchingIDsString("user=carol timed out")); got != "[]" {
+		t.Errorf("expected no IDs, got %s", got)
+	}
+
+	// Literals fire, but the regex does not match.
+	if got := fmt.Sprint(rs.candidates([]byte("error code=x"))); got != "[0]" {
+		t.Errorf("expected candidates [0], got %s", got)
+	}
+	if got := fmt.Sprint(rs.MatchingIDsString("error code=x")); got != "[]" {
+		t.Errorf("expected no IDs, got %s", got)
+	}
+}
+
+func TestRegexSetInvalid(t *testing.T) {
+	if _, err := NewRegexSet([]string{"ok", "(unclosed"}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,259 @@
+package ahocorasick
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The kind of an edge in a graph of a Trie.
+type EdgeKind int
+
+const (
+	TransitionEdge EdgeKind = iota // A transition from a state to its child.
+	FailEdge                       // A fail link.
+	SuffixEdge                     // A dictionary suffix link.
+)
+
+func (k EdgeKind) String() string {
+	switch k {
+	case TransitionEdge:
+		return "transition"
+	case FailEdge:
+		return "fail"
+	case SuffixEdge:
+		return "suffix"
+	}
+	return fmt.Sprintf("EdgeKind(%d)", int(k))
+}
+
+// A GraphRenderer outputs the states and edges visited by a TrieGrapher in some graph format.
+//
+// The TrieGrapher calls Begin, then State and Edge for every state and edge (in depth-first order,
+// with the transition edge to a state following the state's subtree), and finally End. Write
+// errors can be ignored by the renderer: the TrieGrapher stops writing after the first error and
+// reports it.
+type GraphRenderer interface {
+	Begin(w io.Writer)
+	State(w io.Writer, s int64, label string, inDict bool)
+	Edge(w io.Writer, from, to int64, kind EdgeKind)
+	End(w io.Writer)
+}
+
+// A GraphRenderer which can also highlight the states and edges visited when matching input (see
+// TrieGrapher.Highlight). Steps holds the input positions at which a state or edge was visited.
+//
+// HighlightState is called right after State. HighlightEdge is called instead of Edge for visited
+// edges, including fail links not otherwise drawn.
+type GraphHighlighter interface {
+	GraphRenderer
+	HighlightState(w io.Writer, s int64, steps []int64)
+	HighlightEdge(w io.Writer, from, to int64, kind EdgeKind, steps []int64)
+}
+
+// Renders graphs in the DOT graph description language (used by Graphviz). Supports highlighting.
+type DOTRenderer struct{}
+
+// Create a new DOTRenderer.
+func NewDOTRenderer() *DOTRenderer {
+	return &DOTRenderer{}
+}
+
+func (r *DOTRenderer) Begin(w io.Writer) {
+	fmt.Fprintln(w, "digraph T {")
+	fmt.Fprintln(w, "\tnodesep=0.2; ranksep=0.4; splines=false; outputorder=edgesfirst;")
+	fmt.Fprintln(w, "\tnode [shape=circle, style=filled, fillcolor=white, fixedsize=true];")
+	fmt.Fprintln(w, "\tedge [arrowsize=0.5];")
+}
+
+func (r *DOTRenderer) State(w io.Writer, s int64, label string, inDict bool) {
+	if inDict {
+		fmt.Fprintf(w, "\t%d [label=%q, shape=doublecircle];\n", s, label)
+	} else {
+		fmt.Fprintf(w, "\t%d [label=%q];\n", s, label)
+	}
+}
+
+func (r *DOTRenderer) Edge(w io.Writer, from, to int64, kind EdgeKind) {
+	switch kind {
+	case FailEdge:
+		fmt.Fprintf(w, "\t%d -> %d [color=red, constraint=false];\n", from, to)
+	case SuffixEdge:
+		fmt.Fprintf(w, "\t%d -> %d [color=darkgreen, constraint=false];\n", from, to)
+	default:
+		fmt.Fprintf(w, "\t%d -> %d;\n", from, to)
+	}
+}
+
+func (r *DOTRenderer) End(w io.Writer) {
+	fmt.Fprintln(w, "}")
+}
+
+func (r *DOTRenderer) HighlightState(w io.Writer, s int64, steps []int64) {
+	fmt.Fprintf(w, "\t%d [fillcolor=lightblue, xlabel=%q];\n", s, stepsLabel(steps))
+}
+
+func (r *DOTRenderer) HighlightEdge(w io.Writer, from, to int64, kind EdgeKind, steps []int64) {
+	switch kind {
+	case FailEdge:
+		fmt.Fprintf(w, "\t%d -> %d [color=red, penwidth=2, constraint=false, label=%q];\n",
+			from, to, stepsLabel(steps))
+	case SuffixEdge:
+		fmt.Fprintf(w, "\t%d -> %d [color=darkgreen, penwidth=2, constraint=false, label=%q];\n",
+			from, to, stepsLabel(steps))
+	default:
+		fmt.Fprintf(w, "\t%d -> %d [color=blue, penwidth=2, label=%q];\n", from, to, stepsLabel(steps))
+	}
+}
+
+// Join steps with commas.
+func stepsLabel(steps []int64) string {
+	ss := make([]string, len(steps))
+	for i, s := range steps {
+		ss[i] = strconv.FormatInt(s, 10)
+	}
+	return strings.Join(ss, ",")
+}
+
+// Renders graphs as Mermaid flowcharts.
+type MermaidRenderer struct {
+	edges     int64   // Number of edges so far, as Mermaid styles edges by index.
+	failEdges []int64 // Indices of fail edges.
+	suffEdges []int64 // Indices of suffix edges.
+}
+
+// Create a new MermaidRenderer.
+func NewMermaidRenderer() *MermaidRenderer {
+	return &MermaidRenderer{}
+}
+
+func (r *MermaidRenderer) Begin(w io.Writer) {
+	r.edges = 0
+	r.failEdges = make([]int64, 0)
+	r.suffEdges = make([]int64, 0)
+
+	fmt.Fprintln(w, "flowchart TD")
+	fmt.Fprintln(w, "\tclassDef dict stroke-width:3px;")
+}
+
+func (r *MermaidRenderer) State(w io.Writer, s int64, label string, inDict bool) {
+	// Mermaid does not accept empty labels, and quotes must be escaped as entities.
+	if label == "" {
+		label = " "
+	}
+	label = strings.Replace(label, `"`, "#quot;", -1)
+
+	if inDict {
+		fmt.Fprintf(w, "\ts%d(((\"%s\"))):::dict\n", s, label)
+	} else {
+		fmt.Fprintf(w, "\ts%d((\"%s\"))\n", s, label)
+	}
+}
+
+func (r *MermaidRenderer) Edge(w io.Writer, from, to int64, kind EdgeKind) {
+	switch kind {
+	case FailEdge:
+		fmt.Fprintf(w, "\ts%d -.-> s%d\n", from, to)
+		r.failEdges = append(r.failEdges, r.edges)
+	case SuffixEdge:
+		fmt.Fprintf(w, "\ts%d -.-> s%d\n", from, to)
+		r.suffEdges = append(r.suffEdges, r.edges)
+	default:
+		fmt.Fprintf(w, "\ts%d --> s%d\n", from, to)
+	}
+	r.edges++
+}
+
+func (r *MermaidRenderer) End(w io.Writer) {
+	for _, e := range r.failEdges {
+		fmt.Fprintf(w, "\tlinkStyle %d stroke:red;\n", e)
+	}
+	for _, e := range r.suffEdges {
+		fmt.Fprintf(w, "\tlinkStyle %d stroke:darkgreen;\n", e)
+	}
+}
+
+// Renders graphs as GraphML, with node attributes for labels and dictionary states and edge
+// attributes for the kind of edge.
+type GraphMLRenderer struct{}
+
+// Create a new GraphMLRenderer.
+func NewGraphMLRenderer() *GraphMLRenderer {
+	return &GraphMLRenderer{}
+}
+
+func (r *GraphMLRenderer) Begin(w io.Writer) {
+	fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(w, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	fmt.Fprintln(w, `  <key id="label" for="node" attr.name="label" attr.type="string"/>`)
+	fmt.Fprintln(w, `  <key id="dict" for="node" attr.name="dictionary" attr.type="boolean"/>`)
+	fmt.Fprintln(w, `  <key id="kind" for="edge" attr.name="kind" attr.type="string"/>`)
+	fmt.Fprintln(w, `  <graph id="T" edgedefault="directed">`)
+}
+
+func (r *GraphMLRenderer) State(w io.Writer, s int64, label string, inDict bool) {
+	fmt.Fprintf(w, `    <node id="n%d"><data key="label">`, s)
+	xml.EscapeText(w, []byte(label))
+	fmt.Fprintf(w, `</data><data key="dict">%t</data></node>`+"\n", inDict)
+}
+
+func (r *GraphMLRenderer) Edge(w io.Writer, from, to int64, kind EdgeKind) {
+	fmt.Fprintf(w, `    <edge source="n%d" target="n%d"><data key="kind">%s</data></edge>`+"\n", from, to, kind)
+}
+
+func (r *GraphMLRenderer) End(w io.Writer) {
+	fmt.Fprintln(w, `  </graph>`)
+	fmt.Fprintln(w, `</graphml>`)
+}
+
+// Renders graphs as a JSON object with a list of nodes and a list of edges:
+//
+//     {"nodes": [{"id": 0, "label": "", "dict": false}, ...],
+//      "edges": [{"from": 0, "to": 1, "kind": "transition"}, ...]}
+type JSONRenderer struct {
+	graph jsonGraph
+}
+
+type jsonGraph struct {
+	Nodes []jsonNode `json:"nodes"`
+	Edges []jsonEdge `json:"edges"`
+}
+
+type jsonNode struct {
+	ID    int64  `json:"id"`
+	Label string `json:"label"`
+	Dict  bool   `json:"dict"`
+}
+
+type jsonEdge struct {
+	From int64  `json:"from"`
+	To   int64  `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// Create a new JSONRenderer.
+func NewJSONRenderer() *JSONRenderer {
+	return &JSONRenderer{}
+}
+
+func (r *JSONRenderer) Begin(w io.Writer) {
+	r.graph = jsonGraph{
+		Nodes: make([]jsonNode, 0),
+		Edges: make([]jsonEdge, 0),
+	}
+}
+
+func (r *JSONRenderer) State(w io.Writer, s int64, label string, inDict bool) {
+	r.graph.Nodes = append(r.graph.Nodes, jsonNode{s, label, inDict})
+}
+
+func (r *JSONRenderer) Edge(w io.Writer, from, to int64, kind EdgeKind) {
+	r.graph.Edges = append(r.graph.Edges, jsonEdge{from, to, kind.String()})
+}
+
+func (r *JSONRenderer) End(w io.Writer) {
+	json.NewEncoder(w).Encode(r.graph)
+}
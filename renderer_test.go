@@ -0,0 +1,103 @@
+package ahocorasick
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestJSONRenderer(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"he", "she"}).Build()
+
+	var buf bytes.Buffer
+	if _, err := NewTrieGrapher(trie).DrawFailLinks(true).Renderer(NewJSONRenderer()).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var graph jsonGraph
+	if err := json.Unmarshal(buf.Bytes(), &graph); err != nil {
+		t.Fatal(err)
+	}
+
+	// Root, h, he, s, sh, she.
+	if len(graph.Nodes) != 6 {
+		t.Errorf("expected %d nodes, got %d", 6, len(graph.Nodes))
+	}
+
+	kinds := make(map[string]int)
+	for _, e := range graph.Edges {
+		kinds[e.Kind]++
+	}
+
+	// Fail links: sh -> h, she -> he. Suffix link: she -> he.
+	expected := map[string]int{"transition": 5, "fail": 2, "suffix": 1}
+	for kind, n := range expected {
+		if kinds[kind] != n {
+			t.Errorf("expected %d %s edges, got %d", n, kind, kinds[kind])
+		}
+	}
+}
+
+func TestGraphMLRenderer(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"he", "she", "<&>"}).Build()
+
+	var buf bytes.Buffer
+	if _, err := NewTrieGrapher(trie).Renderer(NewGraphMLRenderer()).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	nodes, edges := 0, 0
+	dec := xml.NewDecoder(&buf)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if el, ok := tok.(xml.StartElement); ok {
+			switch el.Name.Local {
+			case "node":
+				nodes++
+			case "edge":
+				edges++
+			}
+		}
+	}
+
+	if nodes != 9 {
+		t.Errorf("expected %d nodes, got %d", 9, nodes)
+	}
+
+	// 8 transitions and the suffix link.
+	if edges != 9 {
+		t.Errorf("expected %d edges, got %d", 9, edges)
+	}
+}
+
+func TestMermaidRenderer(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"he", "she"}).Build()
+
+	var buf bytes.Buffer
+	if _, err := NewTrieGrapher(trie).Renderer(NewMermaidRenderer()).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "flowchart TD\n") {
+		t.Errorf("expected flowchart, got:\n%s", out)
+	}
+
+	if n := strings.Count(out, ":::dict\n"); n != 2 {
+		t.Errorf("expected %d dictionary states, got %d", 2, n)
+	}
+
+	if !strings.Contains(out, "\tlinkStyle 2 stroke:darkgreen;\n") {
+		t.Errorf("expected styled suffix link, got:\n%s", out)
+	}
+}
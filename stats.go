@@ -0,0 +1,95 @@
+package ahocorasick
+
+// Statistics about the size and shape of a Trie.
+type TrieStats struct {
+	NumStates        int64            // Number of states in use, i.e. used cells (including the root).
+	NumPatterns      int64            // Number of patterns in the dictionary.
+	NumCells         int64            // Number of allocated cells in each of the arrays.
+	FillRatio        float64          // Ratio of used to allocated cells.
+	Depths           []int64          // Depths[d] holds the number of states at depth d.
+	MaxDepth         int64            // Depth of the deepest state (the length of the longest pattern).
+	AvgDepth         float64          // Average depth of the states.
+	LongestFailChain int64            // Most fail links followed from any state to reach the root.
+	LongestSuffChain int64            // Most dictionary suffix links followed from any state.
+	ArraySizes       map[string]int64 // Allocated size in bytes of each array, by name.
+	MemoryUsage      int64            // Combined size of the arrays in bytes.
+}
+
+// Compute statistics about the Trie. The Trie is assumed to be valid (see Verify).
+func (tr *Trie) Stats() *TrieStats {
+	st := &TrieStats{
+		NumPatterns: tr.NumPatterns(),
+		NumCells:    int64(len(tr.base)),
+		Depths:      make([]int64, 0),
+		ArraySizes:  make(map[string]int64),
+	}
+
+	depths, _ := tr.depths()
+
+	// States by depth, so that links (which point to shallower states) can be followed in order.
+	byDepth := make([][]int64, 0)
+	var totalDepth int64
+
+	for s, d := range depths {
+		if d == EmptyCell {
+			continue
+		}
+
+		st.NumStates++
+		totalDepth += d
+
+		for int64(len(st.Depths)) <= d {
+			st.Depths = append(st.Depths, 0)
+			byDepth = append(byDepth, make([]int64, 0))
+		}
+		st.Depths[d]++
+		byDepth[d] = append(byDepth[d], int64(s))
+	}
+
+	if st.NumStates > 0 {
+		st.MaxDepth = int64(len(st.Depths)) - 1
+		st.AvgDepth = float64(totalDepth) / float64(st.NumStates)
+	}
+
+	if st.NumCells > 0 {
+		st.FillRatio = float64(st.NumStates) / float64(st.NumCells)
+	}
+
+	// The chain lengths of states with smaller depth are known when we get to a state.
+	failChains := make([]int64, len(depths))
+	suffChains := make([]int64, len(depths))
+
+	for _, states := range byDepth {
+		for _, s := range states {
+			if s != RootState {
+				failChains[s] = failChains[tr.fail[s]] + 1
+			}
+			if f := tr.suff[s]; f != EmptyCell {
+				suffChains[s] = suffChains[f] + 1
+			}
+
+			if failChains[s] > st.LongestFailChain {
+				st.LongestFailChain = failChains[s]
+			}
+			if suffChains[s] > st.LongestSuffChain {
+				st.LongestSuffChain = suffChains[s]
+			}
+		}
+	}
+
+	arrays := map[string][]int64{
+		"base":  tr.base,
+		"check": tr.check,
+		"dict":  tr.dict,
+		"fail":  tr.fail,
+		"suff":  tr.suff,
+		"ids":   tr.ids,
+	}
+
+	for name, arr := range arrays {
+		st.ArraySizes[name] = int64(cap(arr)) * 8
+		st.MemoryUsage += st.ArraySizes[name]
+	}
+
+	return st
+}
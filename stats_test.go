@@ -0,0 +1,70 @@
+package ahocorasick
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+	stats := trie.Stats()
+
+	// Root, h, he, her, hers, hi, his, s, sh, she.
+	if stats.NumStates != 10 {
+		t.Errorf("expected %d states, got %d", 10, stats.NumStates)
+	}
+
+	if stats.NumPatterns != 4 {
+		t.Errorf("expected %d patterns, got %d", 4, stats.NumPatterns)
+	}
+
+	expected := []int64{1, 2, 3, 3, 1}
+	if len(stats.Depths) != len(expected) {
+		t.Fatalf("expected depths %v, got %v", expected, stats.Depths)
+	}
+	for d := range expected {
+		if stats.Depths[d] != expected[d] {
+			t.Errorf("expected %d states at depth %d, got %d", expected[d], d, stats.Depths[d])
+		}
+	}
+
+	if stats.MaxDepth != 4 {
+		t.Errorf("expected max depth %d, got %d", 4, stats.MaxDepth)
+	}
+
+	if stats.AvgDepth != 2.1 {
+		t.Errorf("expected average depth %v, got %v", 2.1, stats.AvgDepth)
+	}
+
+	// hers -> s -> root.
+	if stats.LongestFailChain != 2 {
+		t.Errorf("expected longest fail chain %d, got %d", 2, stats.LongestFailChain)
+	}
+
+	// she -> he.
+	if stats.LongestSuffChain != 1 {
+		t.Errorf("expected longest suffix chain %d, got %d", 1, stats.LongestSuffChain)
+	}
+
+	var total int64
+	for name, size := range stats.ArraySizes {
+		if size < stats.NumCells*8 {
+			t.Errorf("expected size of %s to be at least %d, got %d", name, stats.NumCells*8, size)
+		}
+		total += size
+	}
+
+	if len(stats.ArraySizes) != 6 || stats.MemoryUsage != total {
+		t.Errorf("expected memory usage %d, got %d", total, stats.MemoryUsage)
+	}
+}
+
+func TestStatsChains(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"a", "aa", "aaa", "aaaa"}).Build()
+	stats := trie.Stats()
+
+	if stats.LongestFailChain != 4 {
+		t.Errorf("expected longest fail chain %d, got %d", 4, stats.LongestFailChain)
+	}
+
+	if stats.LongestSuffChain != 3 {
+		t.Errorf("expected longest suffix chain %d, got %d", 3, stats.LongestSuffChain)
+	}
+}
@@ -0,0 +1,125 @@
+package ahocorasick
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// A single step taken by a Trie when matching input, as returned by Trace.
+type TraceStep struct {
+	Pos     int64    // The position of the byte in the input (of the character it belongs to, with a Transform).
+	Byte    byte     // The input byte (after any Transform).
+	From    int64    // The state before the step.
+	To      int64    // The state after the step.
+	Fails   []int64  // The states reached through fail links before the transition (if any).
+	Suffs   []int64  // The states reached through dictionary suffix links to report matches.
+	Matches []*Match // The matches reported after the step.
+}
+
+func (ts *TraceStep) String() string {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "%d %s: %d -> %d", ts.Pos, label(EncodeByte(ts.Byte)), ts.From, ts.To)
+
+	if len(ts.Fails) > 0 {
+		fmt.Fprintf(&buf, " (fail %v)", ts.Fails)
+	}
+
+	if len(ts.Suffs) > 0 {
+		fmt.Fprintf(&buf, " (suffix %v)", ts.Suffs)
+	}
+
+	for _, m := range ts.Matches {
+		fmt.Fprintf(&buf, " %v", m)
+	}
+
+	return buf.String()
+}
+
+// Run the Trie against the provided input like Match, but return every step taken: the state
+// transitions, the fail and suffix links followed and the matches reported. This is useful for
+// debugging why a pattern did or didn't match.
+//
+// With a Transform (e.g. from the options of the Trie) the steps are taken on the transformed input,
+// while positions and matches refer to the original input.
+func (tr *Trie) Trace(input []byte) []*TraceStep {
+	text := input
+	var tf *transformed
+	if tr.transform != nil {
+		tf = transformInput(tr.transform, input)
+		text = tf.out
+	}
+
+	steps := make([]*TraceStep, 0, len(text))
+
+	s := RootState
+
+	for i, c := range text {
+		step := &TraceStep{
+			Pos:     int64(i),
+			Byte:    c,
+			From:    s,
+			Fails:   make([]int64, 0),
+			Suffs:   make([]int64, 0),
+			Matches: make([]*Match, 0),
+		}
+
+		s = tr.traceStep(s, EncodeByte(c), step)
+		step.To = s
+
+		if tr.dict[s] != 0 {
+			step.Matches = append(step.Matches, tr.traceMatch(input, tf, s, int64(i+1)))
+		}
+
+		for f := tr.suff[s]; f != EmptyCell; f = tr.suff[f] {
+			step.Suffs = append(step.Suffs, f)
+			step.Matches = append(step.Matches, tr.traceMatch(input, tf, f, int64(i+1)))
+		}
+
+		if tf != nil {
+			step.Pos = tf.ins[tf.segment(step.Pos)]
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps
+}
+
+// Create the match of the pattern of state s ending at end in the traced input, mapping it back to
+// the original input if transformed.
+func (tr *Trie) traceMatch(input []byte, tf *transformed, s, end int64) *Match {
+	pos := end - tr.dict[s]
+	if tf != nil {
+		pos, end = tf.original(pos, end)
+	}
+	return tr.newMatch(pos, tr.ids[s], input[pos:end])
+}
+
+// Same as step, but records the fail links followed in ts.
+func (tr *Trie) traceStep(s, c int64, ts *TraceStep) int64 {
+	t := tr.base[s] + c
+	if t < int64(len(tr.check)) && tr.check[t] == s {
+		return t
+	}
+
+	for f := tr.fail[s]; f > 0; f = tr.fail[f] {
+		ts.Fails = append(ts.Fails, f)
+
+		t := tr.base[f] + c
+		if t < int64(len(tr.check)) && tr.check[t] == f {
+			return t
+		}
+	}
+
+	if s != RootState {
+		ts.Fails = append(ts.Fails, RootState)
+	}
+
+	t = tr.base[RootState] + c
+	if t < int64(len(tr.check)) && tr.check[t] == RootState {
+		return t
+	}
+
+	return RootState
+}
@@ -0,0 +1,119 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+	steps := trie.Trace([]byte("shis"))
+
+	if len(steps) != 4 {
+		t.Fatalf("expected %d steps, got %d", 4, len(steps))
+	}
+
+	s := trie.step(RootState, EncodeByte('s'))
+	sh := trie.step(s, EncodeByte('h'))
+	h := trie.step(RootState, EncodeByte('h'))
+	hi := trie.step(h, EncodeByte('i'))
+	his := trie.step(hi, EncodeByte('s'))
+
+	cases := []struct {
+		from, to int64
+		fails    []int64
+		matches  int
+	}{
+		{RootState, s, []int64{}, 0},
+		{s, sh, []int64{}, 0},
+		{sh, hi, []int64{h}, 0},
+		{hi, his, []int64{}, 1},
+	}
+
+	for i, c := range cases {
+		step := steps[i]
+
+		if step.Pos != int64(i) || step.From != c.from || step.To != c.to {
+			t.Errorf("step %d: expected %d -> %d, got %v", i, c.from, c.to, step)
+		}
+
+		if len(step.Fails) != len(c.fails) {
+			t.Errorf("step %d: expected fails %v, got %v", i, c.fails, step.Fails)
+		}
+
+		if len(step.Matches) != c.matches {
+			t.Errorf("step %d: expected %d matches, got %d", i, c.matches, len(step.Matches))
+		}
+	}
+
+	if steps[3].Matches[0].MatchString() != "his" {
+		t.Errorf("expected match %q, got %v", "his", steps[3].Matches[0])
+	}
+}
+
+func TestTraceMatchesMatch(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she", "s"}).Build()
+	input := []byte("I have never tasted a hershey bar. She is his.")
+
+	matches := make([]*Match, 0)
+	for _, step := range trie.Trace(input) {
+		matches = append(matches, step.Matches...)
+
+		if step.To != trie.step(step.From, EncodeByte(step.Byte)) {
+			t.Errorf("%v: expected state %d", step, trie.step(step.From, EncodeByte(step.Byte)))
+		}
+	}
+
+	expected := trie.Match(input)
+
+	if len(matches) != len(expected) {
+		t.Fatalf("expected %d matches, got %d", len(expected), len(matches))
+	}
+
+	for i := range matches {
+		if !MatchEqual(matches[i], expected[i]) {
+			t.Errorf("expected %v, got %v", expected[i], matches[i])
+		}
+	}
+}
+
+func TestTraceTransform(t *testing.T) {
+	cases := []struct {
+		trie     *Trie
+		input    string
+		expected string
+	}{
+		{
+			NewTrieBuilderWithOptions(WithCaseFolding(true)).AddString("he").Build(),
+			"uHE",
+			`[{1 "HE"}]`,
+		},
+		{
+			NewTrieBuilderWithOptions(WithNormalization(FormNFC)).AddStrings([]string{"café", "é!"}).Build(),
+			"cafe\u0301!",
+			"[{0 \"cafe\u0301\"} {3 \"e\u0301!\"}]",
+		},
+	}
+
+	for _, c := range cases {
+		input := []byte(c.input)
+
+		matches := make([]*Match, 0)
+		last := int64(0)
+		for _, step := range c.trie.Trace(input) {
+			matches = append(matches, step.Matches...)
+
+			if step.To != c.trie.step(step.From, EncodeByte(step.Byte)) {
+				t.Errorf("%q: %v: expected state %d", c.input, step, c.trie.step(step.From, EncodeByte(step.Byte)))
+			}
+			if step.Pos < last || step.Pos >= int64(len(input)) {
+				t.Errorf("%q: %v: position out of order or range", c.input, step)
+			}
+			last = step.Pos
+		}
+
+		if got, expected := fmt.Sprint(matches), fmt.Sprint(c.trie.Match(input)); got != expected || got != c.expected {
+			t.Errorf("%q: expected %s (like Match: %s), got %s", c.input, c.expected, expected, got)
+		}
+	}
+}
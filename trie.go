@@ -21,10 +21,47 @@ type Trie struct {
 	dict  []int64 // Holds the pattern length of s (if it is in the dictionary).
 	fail  []int64 // Holds the fail link for s.
 	suff  []int64 // Holds the dictionary suffix link for s.
+	ids   []int64 // Holds the pattern ID of s (if it is in the dictionary).
+
+	tags       map[int64][]string // The tags of patterns, by ID.
+	priorities map[int64]int64    // The priorities of patterns, by ID.
+	transform  Transform          // Applied to input before matching, if set.
+	options    Options            // The options the Trie was built with.
+}
+
+// Create a Trie directly from its arrays. The arrays are not copied, and must not be modified
+// afterwards. This is mostly useful for code generated by GenerateGo; use a TrieBuilder otherwise.
+func NewTrie(base, check, dict, fail, suff, ids []int64) *Trie {
+	return &Trie{
+		base:  base,
+		check: check,
+		dict:  dict,
+		fail:  fail,
+		suff:  suff,
+		ids:   ids,
+	}
+}
+
+// Set the tags of patterns by ID (see TrieBuilder.AddPatternWithTags), for a Trie created with
+// NewTrie. Returns the Trie.
+func (tr *Trie) WithTags(tags map[int64][]string) *Trie {
+	tr.tags = tags
+	return tr
+}
+
+// Set the priorities of patterns by ID (see TrieBuilder.AddPatternWithPriority), for a Trie created
+// with NewTrie. Returns the Trie.
+func (tr *Trie) WithPriorities(priorities map[int64]int64) *Trie {
+	tr.priorities = priorities
+	return tr
 }
 
 // Run the Trie against the provided input and returns potentially matches.
 func (tr *Trie) Match(input []byte) []*Match {
+	if tr.usesMatcher() {
+		return tr.matcher().Match(input)
+	}
+
 	matches := make([]*Match, 0)
 
 	s := RootState
@@ -34,39 +71,143 @@ func (tr *Trie) Match(input []byte) []*Match {
 
 		if tr.dict[s] != 0 {
 			pos := int64(i+1) - tr.dict[s]
-			matches = append(matches, newMatch(pos, input[pos:i+1]))
+			matches = append(matches, tr.newMatch(pos, tr.ids[s], input[pos:i+1]))
 		}
 
 		for f := tr.suff[s]; f != EmptyCell; f = tr.suff[f] {
 			pos := int64(i+1) - tr.dict[f]
-			matches = append(matches, newMatch(pos, input[pos:i+1]))
+			matches = append(matches, tr.newMatch(pos, tr.ids[f], input[pos:i+1]))
 		}
 	}
 
 	return matches
 }
 
-// Same as Match, but returns immediately after the first matched pattern.
+// Same as Match, but returns immediately after the first matched pattern. With MatchNonOverlapping
+// the first match can only be known after resolving all of them, so it is the first of Match.
 func (tr *Trie) MatchFirst(input []byte) *Match {
+	if tr.options.MatchKind != MatchAll {
+		if matches := tr.Match(input); len(matches) > 0 {
+			return matches[0]
+		}
+		return nil
+	}
+
+	text, tf := tr.transformedInput(input)
+
 	s := RootState
 
-	for i, c := range input {
+	for i, c := range text {
 		s = tr.step(s, EncodeByte(c))
 
-		if tr.dict[s] != 0 {
-			pos := int64(i+1) - tr.dict[s]
-			return newMatch(pos, input[pos:i+1])
+		f := s
+		if tr.dict[s] == 0 {
+			f = tr.suff[s]
 		}
 
-		if f := tr.suff[s]; f != EmptyCell {
-			pos := int64(i+1) - tr.dict[f]
-			return newMatch(pos, input[pos:i+1])
+		if f != EmptyCell {
+			pos, end := int64(i+1)-tr.dict[f], int64(i+1)
+			if tf != nil {
+				pos, end = tf.original(pos, end)
+			}
+			return tr.newMatch(pos, tr.ids[f], input[pos:end])
 		}
 	}
 
 	return nil
 }
 
+// Count the number of matches in input (the same as len(Match(input)), but without allocating
+// Matches). With MatchNonOverlapping the matches are resolved by Match, which allocates them.
+func (tr *Trie) Count(input []byte) int64 {
+	if tr.options.MatchKind != MatchAll {
+		return int64(len(tr.Match(input)))
+	}
+
+	text, _ := tr.transformedInput(input)
+
+	var n int64
+
+	s := RootState
+
+	for _, c := range text {
+		s = tr.step(s, EncodeByte(c))
+
+		if tr.dict[s] != 0 {
+			n++
+		}
+
+		for f := tr.suff[s]; f != EmptyCell; f = tr.suff[f] {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Count the number of matches in input for each pattern, by pattern ID.
+func (tr *Trie) CountPerPattern(input []byte) map[int64]int64 {
+	counts := make(map[int64]int64)
+
+	if tr.options.MatchKind != MatchAll {
+		for _, match := range tr.Match(input) {
+			counts[match.id]++
+		}
+		return counts
+	}
+
+	text, _ := tr.transformedInput(input)
+
+	s := RootState
+
+	for _, c := range text {
+		s = tr.step(s, EncodeByte(c))
+
+		if tr.dict[s] != 0 {
+			counts[tr.ids[s]]++
+		}
+
+		for f := tr.suff[s]; f != EmptyCell; f = tr.suff[f] {
+			counts[tr.ids[f]]++
+		}
+	}
+
+	return counts
+}
+
+// Check whether any pattern matches input. Like MatchFirst, but without allocating a Match.
+func (tr *Trie) HasMatch(input []byte) bool {
+	// Resolving overlapping matches always keeps one of them, so the MatchKind does not matter.
+	text, _ := tr.transformedInput(input)
+
+	s := RootState
+
+	for _, c := range text {
+		s = tr.step(s, EncodeByte(c))
+
+		if tr.dict[s] != 0 || tr.suff[s] != EmptyCell {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Run the Trie against the provided input and return the matches grouped by the tags of their
+// patterns. A match is included once for each of its tags, and matches of untagged patterns are
+// left out.
+func (tr *Trie) MatchByTag(input []byte) map[string][]*Match {
+	byTag := make(map[string][]*Match)
+
+	for _, match := range tr.Match(input) {
+		for _, tag := range match.tags {
+			byTag[tag] = append(byTag[tag], match)
+		}
+	}
+
+	return byTag
+}
+
 // Helper method to make matching strings a little more comfortable.
 func (tr *Trie) MatchString(input string) []*Match {
 	return tr.Match([]byte(input))
@@ -77,6 +218,26 @@ func (tr *Trie) MatchStringFirst(input string) *Match {
 	return tr.MatchFirst([]byte(input))
 }
 
+// Helper method to make grouping matches in a string by tag a little more comfortable.
+func (tr *Trie) MatchByTagString(input string) map[string][]*Match {
+	return tr.MatchByTag([]byte(input))
+}
+
+// Helper method to make counting matches in a string a little more comfortable.
+func (tr *Trie) CountString(input string) int64 {
+	return tr.Count([]byte(input))
+}
+
+// Helper method to make counting matches per pattern in a string a little more comfortable.
+func (tr *Trie) CountPerPatternString(input string) map[int64]int64 {
+	return tr.CountPerPattern([]byte(input))
+}
+
+// Helper method to make checking a string for matches a little more comfortable.
+func (tr *Trie) HasMatchString(input string) bool {
+	return tr.HasMatch([]byte(input))
+}
+
 func (tr *Trie) NumPatterns() int64 {
 	var c int64 = 0
 	for _, n := range tr.dict {
@@ -87,6 +248,39 @@ func (tr *Trie) NumPatterns() int64 {
 	return c
 }
 
+// Create a match of the pattern with the given ID, along with its tags and priority.
+func (tr *Trie) newMatch(pos, id int64, match []byte) *Match {
+	m := newMatch(pos, id, match)
+	m.tags = tr.tags[id]
+	m.priority = tr.priorities[id]
+	return m
+}
+
+// Check whether matching needs a Matcher, to transform input or resolve overlapping matches.
+func (tr *Trie) usesMatcher() bool {
+	return tr.transform != nil || tr.options.MatchKind != MatchAll
+}
+
+// Get the input transformed by the Transform of the Trie (if any) for matching it without a Matcher,
+// along with the transformation to map matches back to the input (nil without a Transform).
+func (tr *Trie) transformedInput(input []byte) ([]byte, *transformed) {
+	if tr.transform == nil {
+		return input, nil
+	}
+
+	tf := transformInput(tr.transform, input)
+	return tf.out, tf
+}
+
+// Get a Matcher with no options other than those of the Trie.
+func (tr *Trie) matcher() *Matcher {
+	return &Matcher{
+		trie:           tr,
+		transform:      tr.transform,
+		nonOverlapping: tr.options.MatchKind == MatchNonOverlapping,
+	}
+}
+
 func (tr *Trie) step(s, c int64) int64 {
 	t := tr.base[s] + c
 	if t < int64(len(tr.check)) && tr.check[t] == s {
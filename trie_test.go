@@ -144,6 +144,123 @@ func TestMatchFirst(t *testing.T) {
 	}
 }
 
+func TestMatchID(t *testing.T) {
+	trie := NewTrieBuilder().
+		AddStrings([]string{"hers", "his", "he"}).
+		AddPatternWithID([]byte("she"), 10).
+		AddString("hi").
+		Build()
+
+	matches := trie.MatchString("ushers hi")
+	expected := []int64{10, 2, 0, 11}
+
+	if len(matches) != len(expected) {
+		t.Fatalf("expected %d matches, got %d", len(expected), len(matches))
+	}
+
+	for i := range matches {
+		if matches[i].ID() != expected[i] {
+			t.Errorf("%v: expected ID %d, got %d", matches[i], expected[i], matches[i].ID())
+		}
+	}
+}
+
+func TestTags(t *testing.T) {
+	trie := NewTrieBuilder().
+		AddStringWithTags("he", "pronoun", "short").
+		AddStringWithTags("she", "pronoun").
+		AddString("hers").
+		AddPatternWithID([]byte("his"), 10).
+		Tag(10, "pronoun", "pronoun").
+		Build()
+
+	matches := trie.MatchString("ushers his")
+	expected := `[[pronoun] [pronoun short] [] [pronoun]]`
+
+	tags := make([][]string, 0)
+	for _, m := range matches {
+		tags = append(tags, m.Tags())
+	}
+
+	if got := fmt.Sprint(tags); got != expected {
+		t.Errorf("expected tags %s, got %s", expected, got)
+	}
+
+	byTag := trie.MatchByTagString("ushers his")
+	if got := fmt.Sprint(byTag["pronoun"]); got != `[{1 "she"} {2 "he"} {7 "his"}]` {
+		t.Errorf("unexpected pronoun matches: %s", got)
+	}
+	if got := fmt.Sprint(byTag["short"]); got != `[{2 "he"}]` {
+		t.Errorf("unexpected short matches: %s", got)
+	}
+	if len(byTag) != 2 {
+		t.Errorf("expected 2 tags, got %d", len(byTag))
+	}
+}
+
+func TestCount(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+
+	cases := []struct {
+		input    string
+		expected map[int64]int64
+	}{
+		{"I have never tasted a hershey bar.", map[int64]int64{0: 1, 2: 2, 3: 1}},
+		{"she is his", map[int64]int64{1: 1, 2: 1, 3: 1}},
+		{"nothing", map[int64]int64{}},
+	}
+
+	for _, c := range cases {
+		matches := trie.MatchString(c.input)
+
+		if n := trie.CountString(c.input); n != int64(len(matches)) {
+			t.Errorf("%q: expected count %d, got %d", c.input, len(matches), n)
+		}
+
+		if b := trie.HasMatchString(c.input); b != (len(matches) > 0) {
+			t.Errorf("%q: expected HasMatch to be %t, got %t", c.input, len(matches) > 0, b)
+		}
+
+		counts := trie.CountPerPatternString(c.input)
+		if len(counts) != len(c.expected) {
+			t.Errorf("%q: expected counts %v, got %v", c.input, c.expected, counts)
+			continue
+		}
+		for id, n := range c.expected {
+			if counts[id] != n {
+				t.Errorf("%q: expected count %d for pattern %d, got %d", c.input, n, id, counts[id])
+			}
+		}
+	}
+}
+
+func TestCountAllocs(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+	input := []byte("I have never tasted a hershey bar.")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		trie.Count(input)
+		trie.HasMatch(input)
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+
+	// Transforming the input allocates, but no Matches are allocated.
+	trie = NewTrieBuilderWithOptions(WithCaseFolding(true)).AddStrings([]string{"hers", "his", "he", "she"}).Build()
+	countAllocs := func(input []byte) float64 {
+		return testing.AllocsPerRun(100, func() {
+			trie.Count(input)
+			trie.HasMatch(input)
+		})
+	}
+
+	if with, without := countAllocs([]byte("HE SHE HIS HERS")), countAllocs([]byte("xx xxx xxx xxxx")); with != without {
+		t.Errorf("expected as many allocations with matches as without (%v), got %v", without, with)
+	}
+}
+
 func BenchmarkBuildNSF(b *testing.B) {
 	patterns, err := ReadStrings("./test_data/NSF-ordlisten.cleaned.txt")
 	if err != nil {
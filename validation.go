@@ -0,0 +1,153 @@
+package ahocorasick
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A ValidationMode decides how a TrieBuilder handles problematic patterns.
+type ValidationMode int
+
+const (
+	NoValidation ValidationMode = iota // Problems are not checked for (the default).
+	Lenient                            // Problems are collected as warnings (see TrieBuilder.Warnings).
+	Strict                             // The first problem is an error (see TrieBuilder.Err).
+)
+
+var (
+	ErrEmptyPattern     = errors.New("Empty pattern.")
+	ErrDuplicatePattern = errors.New("Duplicate pattern.")
+	ErrPatternTooLong   = errors.New("Pattern too long.")
+	ErrTooManyStates    = errors.New("Too many states.")
+	ErrValidateTooLate  = errors.New("Validate called after adding patterns.")
+)
+
+// A PatternError describes a problem with a pattern added to a TrieBuilder.
+type PatternError struct {
+	Err      error  // One of ErrEmptyPattern, ErrDuplicatePattern, ErrPatternTooLong or ErrTooManyStates.
+	Index    int64  // The index of the pattern, counting every pattern added to the TrieBuilder.
+	Previous int64  // The index of the earlier identical pattern if Err is ErrDuplicatePattern, otherwise -1.
+	Pattern  []byte // The pattern (after any Transform).
+	Limit    int64  // The exceeded limit if Err is ErrPatternTooLong or ErrTooManyStates.
+}
+
+func (e *PatternError) Error() string {
+	switch e.Err {
+	case ErrEmptyPattern:
+		return fmt.Sprintf("Pattern %d is empty.", e.Index)
+	case ErrDuplicatePattern:
+		return fmt.Sprintf("Pattern %d (%q) is a duplicate of pattern %d.", e.Index, e.Pattern, e.Previous)
+	case ErrPatternTooLong:
+		return fmt.Sprintf("Pattern %d (%q) is longer than %d bytes.", e.Index, e.Pattern, e.Limit)
+	case ErrTooManyStates:
+		return fmt.Sprintf("Pattern %d (%q) makes the trie exceed %d states.", e.Index, e.Pattern, e.Limit)
+	}
+	return fmt.Sprintf("Pattern %d (%q): %v", e.Index, e.Pattern, e.Err)
+}
+
+func (e *PatternError) Unwrap() error { return e.Err }
+
+// Set how problems with patterns added afterwards are handled: empty patterns, duplicates, patterns
+// longer than MaxPatternLen and patterns growing the trie beyond MaxStates.
+//
+// In Lenient mode problems are collected as warnings. Empty patterns and patterns which are too long
+// are left out, duplicates are added as usual (keeping the last ID), and the pattern exceeding
+// MaxStates is added (only the first one is reported). In Strict mode the first problem is kept as
+// the error of the TrieBuilder, and every pattern from the problematic one on is left out (except a
+// pattern exceeding MaxStates, which has already been added).
+//
+// Validate should be called before adding patterns (or after Reset), as later patterns are not
+// checked for duplicates of the patterns already added. Otherwise ErrValidateTooLate is reported: in
+// Strict mode it is the error of the TrieBuilder, and no more patterns are added, while in Lenient
+// mode it is a warning, and patterns are still added and checked.
+func (tb *TrieBuilder) Validate(mode ValidationMode) *TrieBuilder {
+	tb.validation = mode
+	if tb.added > 0 {
+		switch {
+		case mode == Strict && tb.err == nil:
+			tb.err = ErrValidateTooLate
+		case mode == Lenient:
+			tb.warnings = append(tb.warnings, ErrValidateTooLate)
+		}
+	}
+	if mode != NoValidation && tb.seen == nil {
+		tb.seen = make(map[string]int64)
+	}
+	return tb
+}
+
+// Set the maximum length of patterns (in bytes, after any Transform) when validating. 0 means no
+// limit.
+func (tb *TrieBuilder) MaxPatternLen(n int64) *TrieBuilder {
+	tb.maxPatternLen = n
+	return tb
+}
+
+// Set the maximum number of states of the trie (including the root) when validating. 0 means no
+// limit.
+func (tb *TrieBuilder) MaxStates(n int64) *TrieBuilder {
+	tb.maxStates = n
+	return tb
+}
+
+// Get the first problem with the added patterns in Strict mode, or nil. It is a *PatternError, or
+// ErrValidateTooLate (see Validate). Invalid options given to NewTrieBuilderWithOptions are an error
+// as well, in any mode.
+func (tb *TrieBuilder) Err() error {
+	return tb.err
+}
+
+// Get the problems with the added patterns in Lenient mode, in the order they were added. They are
+// *PatternErrors, or ErrValidateTooLate (see Validate).
+func (tb *TrieBuilder) Warnings() []error {
+	return tb.warnings
+}
+
+// Check a pattern about to be added, returning whether it should be added.
+func (tb *TrieBuilder) validate(pattern []byte, index int64) bool {
+	if len(pattern) == 0 {
+		tb.report(&PatternError{Err: ErrEmptyPattern, Index: index, Previous: EmptyCell, Pattern: pattern})
+		return false
+	}
+
+	if tb.maxPatternLen > 0 && int64(len(pattern)) > tb.maxPatternLen {
+		tb.report(&PatternError{
+			Err:      ErrPatternTooLong,
+			Index:    index,
+			Previous: EmptyCell,
+			Pattern:  pattern,
+			Limit:    tb.maxPatternLen,
+		})
+		return false
+	}
+
+	if previous, ok := tb.seen[string(pattern)]; ok {
+		tb.report(&PatternError{Err: ErrDuplicatePattern, Index: index, Previous: previous, Pattern: pattern})
+		return tb.validation != Strict
+	}
+
+	tb.seen[string(pattern)] = index
+	return true
+}
+
+// Check the number of states after adding a pattern.
+func (tb *TrieBuilder) validateStates(pattern []byte, index int64) {
+	if tb.maxStates > 0 && tb.numStates > tb.maxStates && !tb.overflowed {
+		tb.overflowed = true
+		tb.report(&PatternError{
+			Err:      ErrTooManyStates,
+			Index:    index,
+			Previous: EmptyCell,
+			Pattern:  pattern,
+			Limit:    tb.maxStates,
+		})
+	}
+}
+
+func (tb *TrieBuilder) report(err *PatternError) {
+	if tb.validation == Strict {
+		tb.err = err
+	} else {
+		tb.warnings = append(tb.warnings, err)
+	}
+}
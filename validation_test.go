@@ -0,0 +1,193 @@
+package ahocorasick
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateLenient(t *testing.T) {
+	tb := NewTrieBuilder().
+		Validate(Lenient).
+		MaxPatternLen(4).
+		AddStrings([]string{"he", "", "hers", "she", "he", "hershey", "his", "she"})
+	trie := tb.Build()
+
+	if tb.Err() != nil {
+		t.Errorf("expected no error, got %v", tb.Err())
+	}
+
+	expected := []string{
+		`Pattern 1 is empty.`,
+		`Pattern 4 ("he") is a duplicate of pattern 0.`,
+		`Pattern 5 ("hershey") is longer than 4 bytes.`,
+		`Pattern 7 ("she") is a duplicate of pattern 3.`,
+	}
+
+	warnings := make([]string, 0)
+	for _, w := range tb.Warnings() {
+		warnings = append(warnings, w.Error())
+	}
+
+	if got := strings.Join(warnings, "\n"); got != strings.Join(expected, "\n") {
+		t.Errorf("expected warnings:\n%s\ngot:\n%s", strings.Join(expected, "\n"), got)
+	}
+
+	var pe *PatternError
+	if !errors.As(tb.Warnings()[1], &pe) || pe.Err != ErrDuplicatePattern || pe.Index != 4 || pe.Previous != 0 {
+		t.Errorf("unexpected duplicate warning: %#v", tb.Warnings()[1])
+	}
+
+	// Duplicates keep the last ID, and patterns which are too long are left out.
+	if got := fmt.Sprint(trie.MatchString("hershey")); got != `[{0 "he"} {0 "hers"} {3 "she"} {4 "he"}]` {
+		t.Errorf("unexpected matches: %s", got)
+	}
+	if ids := fmt.Sprint(trie.MatchString("he she")[0].ID(), trie.MatchString("she")[0].ID()); ids != "4 7" {
+		t.Errorf("expected IDs 4 7, got %s", ids)
+	}
+}
+
+func TestValidateStrict(t *testing.T) {
+	tb := NewTrieBuilder().
+		Validate(Strict).
+		AddStrings([]string{"he", "she", "he", "his"})
+	trie := tb.Build()
+
+	if !errors.Is(tb.Err(), ErrDuplicatePattern) {
+		t.Fatalf("expected duplicate pattern error, got %v", tb.Err())
+	}
+
+	if tb.Err().Error() != `Pattern 2 ("he") is a duplicate of pattern 0.` {
+		t.Errorf("unexpected error: %v", tb.Err())
+	}
+
+	if len(tb.Warnings()) != 0 {
+		t.Errorf("expected no warnings, got %v", tb.Warnings())
+	}
+
+	// Patterns from the problematic one on are left out.
+	if got := fmt.Sprintf("%s", trie.Patterns()); got != "[he she]" {
+		t.Errorf("expected patterns [he she], got %s", got)
+	}
+}
+
+func TestValidateMaxStates(t *testing.T) {
+	// "hers", "his", "he" and "she" make 10 states, including the root.
+	patterns := []string{"hers", "his", "he", "she"}
+
+	tb := NewTrieBuilder().Validate(Lenient).MaxStates(10).AddStrings(patterns)
+	if len(tb.Warnings()) != 0 {
+		t.Fatalf("expected no warnings, got %v", tb.Warnings())
+	}
+
+	tb = NewTrieBuilder().Validate(Lenient).MaxStates(9).AddStrings(patterns).AddString("hi")
+	if len(tb.Warnings()) != 1 || !errors.Is(tb.Warnings()[0], ErrTooManyStates) {
+		t.Fatalf("expected one warning about too many states, got %v", tb.Warnings())
+	}
+	if err := tb.Warnings()[0].(*PatternError); err.Index != 3 || err.Limit != 9 {
+		t.Errorf("expected pattern %d to exceed %d states, got %v", 3, 9, err)
+	}
+
+	if n := tb.Build().NumPatterns(); n != 5 {
+		t.Errorf("expected %d patterns, got %d", 5, n)
+	}
+
+	tb = NewTrieBuilder().Validate(Strict).MaxStates(5).AddString("hers")
+	if tb.Err() != nil {
+		t.Errorf("expected no error, got %v", tb.Err())
+	}
+
+	tb = NewTrieBuilder().Validate(Strict).MaxStates(4).AddString("hers")
+	if !errors.Is(tb.Err(), ErrTooManyStates) {
+		t.Errorf("expected too many states error, got %v", tb.Err())
+	}
+
+	tb = NewTrieBuilder().Validate(Strict).MaxStates(5).AddString("hers").Reset().AddString("hers")
+	if tb.Err() != nil {
+		t.Errorf("expected no error after Reset, got %v", tb.Err())
+	}
+}
+
+func TestValidateTooLate(t *testing.T) {
+	tb := NewTrieBuilder().AddString("he").Validate(Strict).AddStrings([]string{"he", "she"})
+
+	if tb.Err() != ErrValidateTooLate {
+		t.Errorf("expected %v, got %v", ErrValidateTooLate, tb.Err())
+	}
+
+	if n := tb.Build().NumPatterns(); n != 1 {
+		t.Errorf("expected %d pattern, got %d", 1, n)
+	}
+
+	// In Lenient mode the builder is still usable, but misses the duplicate of the earlier pattern.
+	tb = NewTrieBuilder().AddString("he").Validate(Lenient).AddStrings([]string{"he", "she", "", "she"})
+
+	if tb.Err() != nil {
+		t.Errorf("expected no error, got %v", tb.Err())
+	}
+
+	warnings := tb.Warnings()
+	if len(warnings) != 3 || warnings[0] != ErrValidateTooLate ||
+		!errors.Is(warnings[1], ErrEmptyPattern) || !errors.Is(warnings[2], ErrDuplicatePattern) {
+		t.Errorf("expected a late Validate, an empty pattern and a duplicate, got %v", warnings)
+	}
+
+	if n := tb.Build().NumPatterns(); n != 2 {
+		t.Errorf("expected %d patterns, got %d", 2, n)
+	}
+
+	tb.Reset().AddStrings([]string{"he", "he"})
+	if tb.Err() != nil || len(tb.Warnings()) != 1 {
+		t.Errorf("expected one warning after Reset, got %v and %v", tb.Err(), tb.Warnings())
+	}
+}
+
+func TestValidateTagsAndPriorities(t *testing.T) {
+	trie := NewTrieBuilder().
+		Validate(Strict).
+		AddStringWithTags("he", "pronoun").
+		AddStringWithTags("he", "short").
+		AddStringWithPriority("she", 5).
+		Build()
+
+	if tags := trie.tags[0]; fmt.Sprint(tags) != "[pronoun]" {
+		t.Errorf("expected tags %v, got %v", "[pronoun]", tags)
+	}
+	if tags := trie.tags[1]; len(tags) != 0 {
+		t.Errorf("expected no tags for left out pattern, got %v", tags)
+	}
+	if p := trie.priorities[2]; p != 0 {
+		t.Errorf("expected no priority for left out pattern, got %d", p)
+	}
+
+	trie = NewTrieBuilder().
+		Validate(Lenient).
+		MaxPatternLen(3).
+		AddStringWithTags("hers", "long").
+		AddStringWithPriority("hers", 5).
+		AddStringWithTags("he", "short").
+		Build()
+
+	if tags := trie.tags[0]; len(tags) != 0 {
+		t.Errorf("expected no tags for left out pattern, got %v", tags)
+	}
+	if p := trie.priorities[1]; p != 0 {
+		t.Errorf("expected no priority for left out pattern, got %d", p)
+	}
+	if tags := trie.tags[2]; fmt.Sprint(tags) != "[short]" {
+		t.Errorf("expected tags %v, got %v", "[short]", tags)
+	}
+}
+
+func TestNoValidation(t *testing.T) {
+	tb := NewTrieBuilder().MaxPatternLen(1).AddStrings([]string{"", "he", "he"})
+
+	if tb.Err() != nil || len(tb.Warnings()) != 0 {
+		t.Errorf("expected no problems without validation, got %v and %v", tb.Err(), tb.Warnings())
+	}
+
+	if n := tb.Build().NumPatterns(); n != 1 {
+		t.Errorf("expected %d pattern, got %d", 1, n)
+	}
+}
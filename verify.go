@@ -0,0 +1,136 @@
+package ahocorasick
+
+import "fmt"
+
+// Verify checks the structural invariants of the Trie, returning an error describing the first
+// violation found. This is useful for checking tries loaded from file.
+//
+// The following is checked:
+//
+//     - all arrays have the same length
+//     - every transition (check[t] = s) points from a state in use, on a valid symbol
+//     - dictionary states hold the length of their pattern (which equals their depth) and an ID
+//     - fail links point to shallower states (making them acyclic), and the root fails to itself
+//     - suffix links point to shallower dictionary states
+func (tr *Trie) Verify() error {
+	n := int64(len(tr.base))
+
+	for _, arr := range [][]int64{tr.check, tr.dict, tr.fail, tr.suff, tr.ids} {
+		if int64(len(arr)) != n {
+			return fmt.Errorf("Array length mismatch (%d != %d).", len(arr), n)
+		}
+	}
+
+	if n == 0 {
+		return fmt.Errorf("Trie has no root state.")
+	}
+
+	if tr.check[RootState] != EmptyCell {
+		return fmt.Errorf("Root state has a parent (%d).", tr.check[RootState])
+	}
+
+	for s := int64(0); s < n; s++ {
+		if tr.base[s] < 0 {
+			return fmt.Errorf("State %d has negative base (%d).", s, tr.base[s])
+		}
+
+		p := tr.check[s]
+		if p == EmptyCell {
+			continue
+		}
+
+		if p < 0 || p >= n || p == s {
+			return fmt.Errorf("State %d has invalid parent (%d).", s, p)
+		}
+
+		if p != RootState && tr.check[p] == EmptyCell {
+			return fmt.Errorf("State %d has a parent not in use (%d).", s, p)
+		}
+
+		if c := s - tr.base[p]; c < 1 || c > AlphabetSize {
+			return fmt.Errorf("State %d has invalid transition symbol (%d) from %d.", s, c, p)
+		}
+	}
+
+	depths, err := tr.depths()
+	if err != nil {
+		return err
+	}
+
+	for s := int64(0); s < n; s++ {
+		if !tr.inUse(s) {
+			if tr.dict[s] != 0 || tr.ids[s] != EmptyCell || tr.fail[s] != EmptyCell || tr.suff[s] != EmptyCell {
+				return fmt.Errorf("Unused cell %d has dictionary or link values.", s)
+			}
+			continue
+		}
+
+		if d := tr.dict[s]; d != 0 && d != depths[s] {
+			return fmt.Errorf("State %d has pattern length %d, but depth %d.", s, d, depths[s])
+		}
+
+		if id := tr.ids[s]; (tr.dict[s] != 0) != (id >= 0) {
+			return fmt.Errorf("State %d has pattern length %d, but ID %d.", s, tr.dict[s], id)
+		}
+
+		f := tr.fail[s]
+		if s == RootState {
+			if f != RootState {
+				return fmt.Errorf("Root state does not fail to itself (%d).", f)
+			}
+		} else if f < 0 || f >= n || !tr.inUse(f) {
+			return fmt.Errorf("State %d has invalid fail link (%d).", s, f)
+		} else if depths[f] >= depths[s] {
+			return fmt.Errorf("State %d fails to a state which is not shallower (%d).", s, f)
+		}
+
+		if f := tr.suff[s]; f != EmptyCell {
+			if f < 0 || f >= n || !tr.inUse(f) || tr.dict[f] == 0 {
+				return fmt.Errorf("State %d has invalid suffix link (%d).", s, f)
+			} else if depths[f] >= depths[s] {
+				return fmt.Errorf("State %d has suffix link to a state which is not shallower (%d).", s, f)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Check whether s is a state in use (that is, the root or the target of a transition).
+func (tr *Trie) inUse(s int64) bool {
+	return s == RootState || tr.check[s] != EmptyCell
+}
+
+// Compute the depth of every state in use by following the parents in check. Unused cells get
+// depth EmptyCell. Returns an error if a state cannot reach the root.
+func (tr *Trie) depths() ([]int64, error) {
+	n := int64(len(tr.check))
+	depths := make([]int64, n)
+	for i := range depths {
+		depths[i] = EmptyCell
+	}
+	depths[RootState] = 0
+
+	path := make([]int64, 0)
+
+	for s := int64(0); s < n; s++ {
+		if !tr.inUse(s) {
+			continue
+		}
+
+		// Walk up until we find a state with known depth, then assign depths on the way back.
+		path = path[:0]
+		for t := s; depths[t] == EmptyCell; t = tr.check[t] {
+			if int64(len(path)) >= n || tr.check[t] < 0 || tr.check[t] >= n {
+				return nil, fmt.Errorf("State %d does not lead to the root.", s)
+			}
+			path = append(path, t)
+		}
+
+		for i := len(path) - 1; i >= 0; i-- {
+			depths[path[i]] = depths[tr.check[path[i]]] + 1
+		}
+	}
+
+	return depths, nil
+}
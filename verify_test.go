@@ -0,0 +1,38 @@
+package ahocorasick
+
+import "testing"
+
+func TestVerify(t *testing.T) {
+	trie := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she", "\x00\xff"}).Build()
+
+	if err := trie.Verify(); err != nil {
+		t.Fatalf("expected valid trie, got %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		corrupt func(tr *Trie)
+	}{
+		{"ArrayLength", func(tr *Trie) { tr.fail = tr.fail[:len(tr.fail)-1] }},
+		{"RootParent", func(tr *Trie) { tr.check[RootState] = 1 }},
+		{"NegativeBase", func(tr *Trie) { tr.base[RootState] = -2 }},
+		{"PatternLength", func(tr *Trie) { tr.dict[tr.step(RootState, EncodeByte('h'))] = 3 }},
+		{"FailLink", func(tr *Trie) {
+			s := tr.step(tr.step(RootState, EncodeByte('h')), EncodeByte('e'))
+			tr.fail[s] = s
+		}},
+		{"SuffLink", func(tr *Trie) {
+			s := tr.step(tr.step(RootState, EncodeByte('h')), EncodeByte('e'))
+			tr.suff[s] = RootState
+		}},
+	}
+
+	for _, c := range cases {
+		tr := NewTrieBuilder().AddStrings([]string{"hers", "his", "he", "she"}).Build()
+		c.corrupt(tr)
+
+		if err := tr.Verify(); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+	}
+}
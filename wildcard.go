@@ -0,0 +1,433 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// The maximum number of atoms an anchor may expand to (see chooseAnchor).
+const maxAnchorAtoms = 16
+
+// A ByteSet is a set of bytes, used to match a single byte of input against a number of
+// alternatives.
+type ByteSet [4]uint64
+
+// Create a ByteSet containing the given bytes.
+func ByteSetOf(bs ...byte) ByteSet {
+	var set ByteSet
+	for _, b := range bs {
+		set.Add(b)
+	}
+	return set
+}
+
+// Create a ByteSet containing every byte.
+func AnyByte() ByteSet {
+	return ByteSet{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+}
+
+// Add b to the set.
+func (set *ByteSet) Add(b byte) {
+	set[b/64] |= 1 << (b % 64)
+}
+
+// Check whether b is in the set.
+func (set ByteSet) Contains(b byte) bool {
+	return set[b/64]&(1<<(b%64)) != 0
+}
+
+// Get the number of bytes in the set.
+func (set ByteSet) Len() int {
+	return bits.OnesCount64(set[0]) + bits.OnesCount64(set[1]) +
+		bits.OnesCount64(set[2]) + bits.OnesCount64(set[3])
+}
+
+// Get the bytes in the set, in increasing order.
+func (set ByteSet) Bytes() []byte {
+	bs := make([]byte, 0, set.Len())
+	for c := 0; c < 256; c++ {
+		if set.Contains(byte(c)) {
+			bs = append(bs, byte(c))
+		}
+	}
+	return bs
+}
+
+// A WildcardPattern is a fixed-length pattern where each byte of input is matched against a
+// ByteSet, allowing wildcards and alternatives.
+type WildcardPattern []ByteSet
+
+// Parse a WildcardPattern from hex, where "??" matches any byte and "[4D|5A]" matches any of the
+// listed bytes. Whitespace is ignored, e.g. "4D 5A ?? [00|01]".
+func ParseWildcard(s string) (WildcardPattern, error) {
+	s = strings.Join(strings.Fields(s), "")
+	p := make(WildcardPattern, 0, len(s)/2)
+
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "??"):
+			p = append(p, AnyByte())
+			i += 2
+		case s[i] == '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("Unterminated byte set at offset %d.", i)
+			}
+
+			var set ByteSet
+			for _, h := range strings.Split(s[i+1:i+end], "|") {
+				b, ok := parseHexByte(h)
+				if !ok {
+					return nil, fmt.Errorf("Invalid byte %q in byte set at offset %d.", h, i)
+				}
+				set.Add(b)
+			}
+
+			p = append(p, set)
+			i += end + 1
+		default:
+			h := s[i:]
+			if len(h) > 2 {
+				h = h[:2]
+			}
+
+			b, ok := parseHexByte(h)
+			if !ok {
+				return nil, fmt.Errorf("Invalid byte %q at offset %d.", h, i)
+			}
+
+			p = append(p, ByteSetOf(b))
+			i += 2
+		}
+	}
+
+	return p, nil
+}
+
+// Parse exactly two hex digits.
+func parseHexByte(h string) (byte, bool) {
+	if len(h) != 2 {
+		return 0, false
+	}
+
+	hi, ok1 := hexDigit(h[0])
+	lo, ok2 := hexDigit(h[1])
+
+	return hi<<4 | lo, ok1 && ok2
+}
+
+func hexDigit(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// Format the pattern in the syntax accepted by ParseWildcard.
+func (p WildcardPattern) String() string {
+	parts := make([]string, len(p))
+
+	for i, set := range p {
+		switch bs := set.Bytes(); {
+		case len(bs) == 256:
+			parts[i] = "??"
+		case len(bs) == 1:
+			parts[i] = fmt.Sprintf("%02X", bs[0])
+		default:
+			alts := make([]string, len(bs))
+			for j, b := range bs {
+				alts[j] = fmt.Sprintf("%02X", b)
+			}
+			parts[i] = "[" + strings.Join(alts, "|") + "]"
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Check whether the pattern matches input at pos.
+func (p WildcardPattern) matchAt(input []byte, pos int64) bool {
+	if pos < 0 || pos+int64(len(p)) > int64(len(input)) {
+		return false
+	}
+
+	for i, set := range p {
+		if !set.Contains(input[pos+int64(i)]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Choose the anchor of a pattern: the run of byte sets p[from:to] which is searched for with a
+// Trie, before verifying the rest of the pattern. This is the longest run which expands to at most
+// maxAnchorAtoms literal atoms, or otherwise the smallest byte set.
+func chooseAnchor(p WildcardPattern) (from, to int) {
+	for i := range p {
+		n := 1
+		for j := i; j < len(p); j++ {
+			if n *= p[j].Len(); n > maxAnchorAtoms {
+				break
+			}
+			if j+1-i > to-from {
+				from, to = i, j+1
+			}
+		}
+	}
+
+	if to == 0 {
+		for i := range p {
+			if p[i].Len() < p[from].Len() {
+				from = i
+			}
+		}
+		to = from + 1
+	}
+
+	return from, to
+}
+
+// Expand a run of byte sets into every literal atom it matches.
+func expandAtoms(p WildcardPattern) [][]byte {
+	atoms := [][]byte{{}}
+
+	for _, set := range p {
+		next := make([][]byte, 0, len(atoms)*set.Len())
+		for _, atom := range atoms {
+			for _, b := range set.Bytes() {
+				next = append(next, append(append(make([]byte, 0, len(p)), atom...), b))
+			}
+		}
+		atoms = next
+	}
+
+	return atoms
+}
+
+// A fixed-length run of byte sets in a wildcardVariant, preceded by a jump over min to max bytes.
+type wildcardChunk struct {
+	min, max int64
+	sets     WildcardPattern
+}
+
+// One way of matching a pattern: runs of byte sets separated by jumps. A WildcardPattern is a
+// single chunk, while a HexString may need several variants to cover its alternations.
+type wildcardVariant struct {
+	id     int64
+	chunks []wildcardChunk
+}
+
+// Choose the anchor of a variant: the chunk c and run of byte sets in it with the longest anchor,
+// preferring fewer atoms between anchors of the same length.
+func (v *wildcardVariant) chooseAnchor() (c, from, to int) {
+	best := 0
+
+	for i, chunk := range v.chunks {
+		if len(chunk.sets) == 0 {
+			continue
+		}
+
+		f, t := chooseAnchor(chunk.sets)
+		n := atomCount(chunk.sets[f:t])
+
+		if t-f > to-from || (t-f == to-from && n < best) {
+			c, from, to, best = i, f, t, n
+		}
+	}
+
+	return c, from, to
+}
+
+// Get the number of atoms a run of byte sets expands to.
+func atomCount(p WildcardPattern) int {
+	n := 1
+	for _, set := range p {
+		n *= set.Len()
+	}
+	return n
+}
+
+// Find the shortest match of the variant where chunk c starts at pos, returning its span.
+func (v *wildcardVariant) matchAround(input []byte, c int, pos int64) (int64, int64, bool) {
+	if !v.chunks[c].sets.matchAt(input, pos) {
+		return 0, 0, false
+	}
+
+	starts := []int64{pos}
+	for i := c; i > 0 && len(starts) > 0; i-- {
+		starts = stepChunk(input, starts, v.chunks[i].min, v.chunks[i].max, v.chunks[i-1].sets, true)
+	}
+
+	ends := []int64{pos + int64(len(v.chunks[c].sets))}
+	for i := c + 1; i < len(v.chunks) && len(ends) > 0; i++ {
+		ends = stepChunk(input, ends, v.chunks[i].min, v.chunks[i].max, v.chunks[i].sets, false)
+	}
+
+	if len(starts) == 0 || len(ends) == 0 {
+		return 0, 0, false
+	}
+
+	start, end := starts[0], ends[0]
+	for _, p := range starts {
+		if p > start {
+			start = p
+		}
+	}
+	for _, p := range ends {
+		if p < end {
+			end = p
+		}
+	}
+
+	return start, end, true
+}
+
+// Get the positions reached from positions by jumping over min to max bytes and matching sets.
+// Going forward, positions are ends of matches so far and the new ends are returned. Going
+// backward, positions are starts and the new starts are returned.
+func stepChunk(input []byte, positions []int64, min, max int64, sets WildcardPattern,
+	backward bool) []int64 {
+
+	n := int64(len(sets))
+	seen := make(map[int64]bool)
+	next := make([]int64, 0, len(positions))
+
+	for _, p := range positions {
+		for gap := min; gap <= max; gap++ {
+			start, q := p+gap, p+gap+n
+			if backward {
+				start, q = p-gap-n, p-gap-n
+			}
+
+			if !seen[q] && sets.matchAt(input, start) {
+				seen[q] = true
+				next = append(next, q)
+			}
+		}
+	}
+
+	return next
+}
+
+// A WildcardBuilder builds a WildcardTrie.
+type WildcardBuilder struct {
+	variants []wildcardVariant
+	nextID   int64 // The ID given to the next pattern.
+}
+
+// Create a new WildcardBuilder.
+func NewWildcardBuilder() *WildcardBuilder {
+	return &WildcardBuilder{
+		variants: make([]wildcardVariant, 0),
+	}
+}
+
+// Add a pattern to be built into the WildcardTrie. The pattern is given an ID equal to the number of
+// patterns added before it (see Match.ID).
+func (wb *WildcardBuilder) AddWildcard(p WildcardPattern) *WildcardBuilder {
+	wb.variants = append(wb.variants, wildcardVariant{
+		id:     wb.nextID,
+		chunks: []wildcardChunk{{sets: p}},
+	})
+	wb.nextID++
+	return wb
+}
+
+// A helper method to make adding multiple patterns a little more comfortable.
+func (wb *WildcardBuilder) AddWildcards(ps []WildcardPattern) *WildcardBuilder {
+	for _, p := range ps {
+		wb.AddWildcard(p)
+	}
+	return wb
+}
+
+// Build the WildcardTrie. A Trie is built from the literal atoms of an anchor chosen from each
+// pattern, and each match of an atom is verified against the whole pattern.
+func (wb *WildcardBuilder) Build() *WildcardTrie {
+	wt := &WildcardTrie{
+		variants: append([]wildcardVariant(nil), wb.variants...),
+		refs:     make([][]atomRef, 0),
+	}
+
+	tb := NewTrieBuilder()
+	atomIDs := make(map[string]int64)
+
+	for i := range wt.variants {
+		c, from, to := wt.variants[i].chooseAnchor()
+		if to == 0 {
+			continue // Empty patterns never match.
+		}
+
+		for _, atom := range expandAtoms(wt.variants[i].chunks[c].sets[from:to]) {
+			id, ok := atomIDs[string(atom)]
+			if !ok {
+				id = int64(len(wt.refs))
+				atomIDs[string(atom)] = id
+				wt.refs = append(wt.refs, nil)
+				tb.AddPatternWithID(atom, id)
+			}
+			wt.refs[id] = append(wt.refs[id], atomRef{int64(i), c, int64(from)})
+		}
+	}
+
+	wt.trie = tb.Build()
+
+	return wt
+}
+
+// A WildcardTrie matches WildcardPatterns (and HexStrings), using a Trie to find candidates.
+type WildcardTrie struct {
+	trie     *Trie             // Matches the atoms.
+	variants []wildcardVariant // The variants of the patterns.
+	refs     [][]atomRef       // The variants anchored by each atom, indexed by atom ID.
+}
+
+// A reference from an atom to a variant it anchors.
+type atomRef struct {
+	variant int64 // The index of the variant.
+	chunk   int   // The chunk of the variant containing the atom.
+	offset  int64 // The offset of the atom in the chunk.
+}
+
+// Run the WildcardTrie against the provided input and return matches, ordered by their end
+// position. Match.Match returns the matched bytes of the input.
+func (wt *WildcardTrie) Match(input []byte) []*Match {
+	matches := make([]*Match, 0)
+
+	// Variants of the same pattern may match the same span.
+	type span struct{ id, start, end int64 }
+	seen := make(map[span]bool)
+
+	for _, atom := range wt.trie.Match(input) {
+		for _, ref := range wt.refs[atom.ID()] {
+			v := &wt.variants[ref.variant]
+
+			start, end, ok := v.matchAround(input, ref.chunk, atom.Pos()-ref.offset)
+			if !ok || seen[span{v.id, start, end}] {
+				continue
+			}
+
+			seen[span{v.id, start, end}] = true
+			matches = append(matches, newMatch(start, v.id, input[start:end]))
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].End() < matches[j].End()
+	})
+
+	return matches
+}
+
+// Helper method to make matching strings a little more comfortable.
+func (wt *WildcardTrie) MatchString(input string) []*Match {
+	return wt.Match([]byte(input))
+}
@@ -0,0 +1,117 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestByteSet(t *testing.T) {
+	set := ByteSetOf(0x00, 'a', 0xff)
+
+	if set.Len() != 3 {
+		t.Errorf("expected 3 bytes, got %d", set.Len())
+	}
+
+	if !set.Contains('a') || set.Contains('b') || !set.Contains(0xff) {
+		t.Errorf("unexpected contents: %q", set.Bytes())
+	}
+
+	if n := AnyByte().Len(); n != 256 {
+		t.Errorf("expected 256 bytes, got %d", n)
+	}
+}
+
+func TestParseWildcard(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"4d5a", "4D 5A"},
+		{"4D 5A ?? 90", "4D 5A ?? 90"},
+		{"[4d|5a] ??[00]", "[4D|5A] ?? 00"},
+		{"", ""},
+	}
+
+	for _, c := range cases {
+		p, err := ParseWildcard(c.input)
+		if err != nil {
+			t.Errorf("%q: %v", c.input, err)
+		} else if p.String() != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.input, c.expected, p)
+		}
+	}
+
+	for _, input := range []string{"4", "4g", "[4D|5]", "[4D", "?"} {
+		if _, err := ParseWildcard(input); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}
+
+func TestChooseAnchor(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		from, to int
+	}{
+		{"4D 5A 90", 0, 3},
+		{"?? 4D ?? 5A 90", 3, 5},
+		{"[00|01] [02|03] ?? 04", 0, 2},
+		{"?? ?? ??", 0, 1},
+		{"?? [00|01|02|03|04|05|06|07|08|09|0A|0B|0C|0D|0E|0F|10] ??", 1, 2},
+	}
+
+	for _, c := range cases {
+		p, err := ParseWildcard(c.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if from, to := chooseAnchor(p); from != c.from || to != c.to {
+			t.Errorf("%q: expected anchor [%d:%d], got [%d:%d]", c.pattern, c.from, c.to, from, to)
+		}
+	}
+}
+
+func TestWildcardTrieMatch(t *testing.T) {
+	patterns := make([]WildcardPattern, 0)
+	for _, s := range []string{"4D 5A ?? 00", "[50|52] 4B", "?? ??", "68 [65|69]", ""} {
+		p, err := ParseWildcard(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		patterns = append(patterns, p)
+	}
+
+	wt := NewWildcardBuilder().AddWildcards(patterns).Build()
+	matches := wt.MatchString("MZ\x90\x00PK RK hi he")
+
+	expected := `[{0 "MZ"} {1 "Z\x90"} {0 "MZ\x90\x00"} {2 "\x90\x00"} {3 "\x00P"} {4 "PK"} ` +
+		`{4 "PK"} {5 "K "} {6 " R"} {7 "RK"} {7 "RK"} {8 "K "} {9 " h"} {10 "hi"} {10 "hi"} {11 "i "} ` +
+		`{12 " h"} {13 "he"} {13 "he"}]`
+
+	if got := fmt.Sprint(matches); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	ids := make(map[int64]int)
+	for _, m := range matches {
+		ids[m.ID()]++
+	}
+
+	if ids[0] != 1 || ids[1] != 2 || ids[2] != 14 || ids[3] != 2 || ids[4] != 0 {
+		t.Errorf("unexpected matches per pattern: %v", ids)
+	}
+}
+
+func TestWildcardTrieEdges(t *testing.T) {
+	p, _ := ParseWildcard("?? 41 ??")
+	wt := NewWildcardBuilder().AddWildcard(p).Build()
+
+	// Candidates too close to the start or end of the input are rejected.
+	if matches := wt.MatchString("A"); len(matches) != 0 {
+		t.Errorf("expected no matches, got %v", matches)
+	}
+
+	if matches := wt.MatchString("AAA"); fmt.Sprint(matches) != `[{0 "AAA"}]` {
+		t.Errorf("expected one match, got %v", matches)
+	}
+}